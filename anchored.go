@@ -10,7 +10,7 @@ import (
 type AnchoredLogger struct {
 	parent   Logger
 	line     int32
-	minLevel Level // defaults to Transient
+	minLevel atomicLevel // defaults to Transient
 
 	mutex     sync.RWMutex
 	fnOnClose func()
@@ -39,16 +39,16 @@ func (l *AnchoredLogger) Parent() Logger {
 }
 
 func (l *AnchoredLogger) MinLevel() Level {
-	return l.minLevel
+	return l.minLevel.Load()
 }
 
 func (l *AnchoredLogger) SetMinLevel(level Level) Logger {
-	l.minLevel = level
+	l.minLevel.Store(level)
 	return l
 }
 
 func (l *AnchoredLogger) LogImpl(level Level, msg string, fielders []Fielder, opts []PrinterOption, d ImplData) {
-	d.MergeMinLevel(l.minLevel)
+	d.MergeMinLevel(l.minLevel.Load())
 
 	var line int32 // 0 if not targetting an anchored line
 
@@ -69,6 +69,11 @@ func (l *AnchoredLogger) Transient(msg string, fielders ...Fielder) Logger {
 	return l
 }
 
+func (l *AnchoredLogger) Trace(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Trace, msg, fielders, nil, ImplData{})
+	return l
+}
+
 func (l *AnchoredLogger) Verbose(msg string, fielders ...Fielder) Logger {
 	l.LogImpl(Verbose, msg, fielders, nil, ImplData{})
 	return l
@@ -89,7 +94,16 @@ func (l *AnchoredLogger) Error(msg string, fielders ...Fielder) Logger {
 	return l
 }
 
+func (l *AnchoredLogger) Fatal(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Fatal, msg, fielders, nil, ImplData{})
+	fatalExit(l)
+	return l
+}
+
 func (l *AnchoredLogger) Log(level Level, msg string, fielders ...Fielder) Logger {
 	l.LogImpl(level, msg, fielders, nil, ImplData{})
+	if level == Fatal {
+		fatalExit(l)
+	}
 	return l
 }
@@ -0,0 +1,311 @@
+package frog
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Any adds a field for a value of any type, picking the best representation for that type:
+// - bools, numbers, strings, []byte, time.Duration, time.Time, and errors use the matching typed field
+// - fmt.Stringers use Stringer
+// - anything else (structs, maps, slices, etc) uses JSONValue
+func Any(name string, value interface{}) FieldAny {
+	return FieldAny{Name: name, Value: value}
+}
+
+// Stringer adds a string field whose value is the result of calling the passed value's String
+// method. If String panics (e.g. because of a nil pointer), the panic is recorded instead.
+func Stringer(name string, value fmt.Stringer) FieldStringer {
+	return FieldStringer{Name: name, Value: value}
+}
+
+// JSONValue adds a field for an arbitrary value, which JSONPrinter renders as the output of
+// json.Marshal, and TextPrinter renders similarly to fmt's "%+v" verb.
+// To protect against cycles and huge values, the value is walked with limits on depth and size
+// before marshaling, and if those limits are hit, the value is rendered as a truncated string
+// instead.
+func JSONValue(name string, value interface{}) FieldJSON {
+	return FieldJSON{Name: name, Value: value}
+}
+
+// Any
+
+type FieldAny struct {
+	Name  string
+	Value interface{}
+}
+
+func (f FieldAny) Field() Field {
+	switch v := f.Value.(type) {
+	case nil:
+		return Field{Name: f.Name, Value: "null"}
+	case bool:
+		return Bool(f.Name, v).Field()
+	case int:
+		return Int(f.Name, v).Field()
+	case int8:
+		return Int8(f.Name, v).Field()
+	case int16:
+		return Int16(f.Name, v).Field()
+	case int32:
+		return Int32(f.Name, v).Field()
+	case int64:
+		return Int64(f.Name, v).Field()
+	case uint:
+		return Uint(f.Name, v).Field()
+	case uint8:
+		return Uint8(f.Name, v).Field()
+	case uint16:
+		return Uint16(f.Name, v).Field()
+	case uint32:
+		return Uint32(f.Name, v).Field()
+	case uint64:
+		return Uint64(f.Name, v).Field()
+	case float32:
+		return Float32(f.Name, v).Field()
+	case float64:
+		return Float64(f.Name, v).Field()
+	case string:
+		return String(f.Name, v).Field()
+	case []byte:
+		return Bytes(f.Name, v).Field()
+	case time.Duration:
+		return Duration(f.Name, v).Field()
+	case time.Time:
+		return Time(f.Name, v).Field()
+	case error:
+		return FieldError{Name: f.Name, Value: v}.Field()
+	case fmt.Stringer:
+		return Stringer(f.Name, v).Field()
+	}
+	return JSONValue(f.Name, f.Value).Field()
+}
+
+// Stringer
+
+type FieldStringer struct {
+	Name  string
+	Value fmt.Stringer
+}
+
+func (f FieldStringer) Field() Field {
+	if f.Value == nil {
+		return Field{Name: f.Name, Value: "null"}
+	}
+	// fmt already handles calling String and recovering from any panics
+	return Field{Name: f.Name, Value: fmt.Sprint(f.Value), IsJSONString: true}
+}
+
+// JSON
+
+type FieldJSON struct {
+	Name  string
+	Value interface{}
+}
+
+func (f FieldJSON) Field() Field {
+	text, ok := formatAny(f.Value)
+	out := Field{Name: f.Name, TextValue: QuoteText(text)}
+	if ok {
+		if js, err := marshalAny(f.Value); err == nil {
+			out.Value = string(js)
+			return out
+		}
+	}
+	// if the value was too big, or couldn't be marshaled, then fall back to the text rendering
+	out.Value = text
+	out.IsJSONString = true
+	return out
+}
+
+func marshalAny(v interface{}) (js []byte, err error) {
+	// a custom MarshalJSON could panic
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while marshaling: %v", r)
+		}
+	}()
+	return json.Marshal(v)
+}
+
+const (
+	anyMaxDepth = 8    // how deeply nested values can be
+	anyMaxItems = 64   // how many elements of a slice, array, map, or struct are shown
+	anyMaxLen   = 1024 // how many bytes of output to allow before truncating
+)
+
+// formatAny renders the value in a compact form similar to fmt's "%+v" verb, and returns false if
+// it had to truncate the value or break a cycle.
+func formatAny(v interface{}) (string, bool) {
+	w := anyWriter{visited: make(map[uintptr]bool)}
+	w.write(reflect.ValueOf(v), 0)
+	out := w.sb.String()
+	if w.sb.Len() > anyMaxLen {
+		w.truncated = true
+		out = cutString(out, anyMaxLen) + "..."
+	}
+	return out, !w.truncated
+}
+
+type anyWriter struct {
+	sb        strings.Builder
+	visited   map[uintptr]bool // pointers being walked (to detect cycles)
+	truncated bool
+}
+
+var (
+	typeError    = reflect.TypeOf((*error)(nil)).Elem()
+	typeStringer = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+func (w *anyWriter) write(v reflect.Value, depth int) {
+	if w.sb.Len() > anyMaxLen {
+		w.truncated = true
+		return
+	}
+	if !v.IsValid() {
+		w.sb.WriteString("<nil>")
+		return
+	}
+	if depth > anyMaxDepth {
+		w.sb.WriteString("...")
+		w.truncated = true
+		return
+	}
+
+	// like fmt, prefer the Error and String methods if they are available
+	if v.CanInterface() && (v.Type().Implements(typeError) || v.Type().Implements(typeStringer)) {
+		if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+			w.sb.WriteString("<nil>")
+			return
+		}
+		w.sb.WriteString(fmt.Sprint(v.Interface()))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		w.sb.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w.sb.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		w.sb.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32:
+		w.sb.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 32))
+	case reflect.Float64:
+		w.sb.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	case reflect.Complex64, reflect.Complex128:
+		w.sb.WriteString(fmt.Sprint(v.Complex()))
+	case reflect.String:
+		w.sb.WriteString(v.String())
+	case reflect.Interface:
+		if v.IsNil() {
+			w.sb.WriteString("<nil>")
+			return
+		}
+		w.write(v.Elem(), depth)
+	case reflect.Ptr:
+		if v.IsNil() {
+			w.sb.WriteString("<nil>")
+			return
+		}
+		ptr := v.Pointer()
+		if w.visited[ptr] {
+			w.sb.WriteString("<cycle>")
+			w.truncated = true
+			return
+		}
+		w.visited[ptr] = true
+		if depth == 0 {
+			w.sb.WriteByte('&')
+		}
+		w.write(v.Elem(), depth+1)
+		delete(w.visited, ptr)
+	case reflect.Struct:
+		t := v.Type()
+		w.sb.WriteByte('{')
+		for i := 0; i < v.NumField(); i++ {
+			if i > 0 {
+				w.sb.WriteByte(' ')
+			}
+			if i >= anyMaxItems {
+				w.sb.WriteString("...")
+				w.truncated = true
+				break
+			}
+			w.sb.WriteString(t.Field(i).Name)
+			w.sb.WriteByte(':')
+			w.write(v.Field(i), depth+1)
+		}
+		w.sb.WriteByte('}')
+	case reflect.Map:
+		if v.IsNil() {
+			w.sb.WriteString("map[]")
+			return
+		}
+		ptr := v.Pointer()
+		if w.visited[ptr] {
+			w.sb.WriteString("<cycle>")
+			w.truncated = true
+			return
+		}
+		w.visited[ptr] = true
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		w.sb.WriteString("map[")
+		for i, key := range keys {
+			if i > 0 {
+				w.sb.WriteByte(' ')
+			}
+			if i >= anyMaxItems {
+				w.sb.WriteString("...")
+				w.truncated = true
+				break
+			}
+			w.write(key, depth+1)
+			w.sb.WriteByte(':')
+			w.write(v.MapIndex(key), depth+1)
+		}
+		w.sb.WriteByte(']')
+		delete(w.visited, ptr)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice {
+			if v.IsNil() {
+				w.sb.WriteString("[]")
+				return
+			}
+			ptr := v.Pointer()
+			if w.visited[ptr] && v.Len() > 0 {
+				w.sb.WriteString("<cycle>")
+				w.truncated = true
+				return
+			}
+			w.visited[ptr] = true
+			defer delete(w.visited, ptr)
+		}
+		w.sb.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				w.sb.WriteByte(' ')
+			}
+			if i >= anyMaxItems {
+				w.sb.WriteString("...")
+				w.truncated = true
+				break
+			}
+			w.write(v.Index(i), depth+1)
+		}
+		w.sb.WriteByte(']')
+	default:
+		// channels, funcs, and unsafe pointers can't be marshaled
+		w.sb.WriteString(fmt.Sprintf("<%s>", v.Type()))
+		w.truncated = true
+	}
+}
@@ -0,0 +1,155 @@
+package frog
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testAnyPoint struct {
+	X, Y int
+	Tag  string `json:"tag,omitempty"`
+}
+
+type testAnyNode struct {
+	Name string
+	Next *testAnyNode
+}
+
+type testNilStringer struct {
+	name string
+}
+
+func (s *testNilStringer) String() string {
+	return s.name
+}
+
+type testNilError struct {
+	msg string
+}
+
+func (e *testNilError) Error() string {
+	return e.msg
+}
+
+type testPanicError struct{}
+
+func (testPanicError) Error() string {
+	panic("boom")
+}
+
+func Test_Any(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    interface{}
+		Expected Field
+	}{
+		{"nil", nil, Field{Name: "nil", Value: "null"}},
+		{"bool", true, Bool("bool", true).Field()},
+		{"int", 42, Int("int", 42).Field()},
+		{"uint8", uint8(7), Uint8("uint8", 7).Field()},
+		{"float64", 1.5, Float64("float64", 1.5).Field()},
+		{"string", "hi there", String("string", "hi there").Field()},
+		{"duration", time.Second, Duration("duration", time.Second).Field()},
+		{"time", time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC), Time("time", time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC)).Field()},
+		{"error", errors.New("oops"), Field{Name: "error", Value: "oops", IsJSONString: true, Kind: KindError}},
+		{"stringer", net.IPv4(127, 0, 0, 1), Field{Name: "stringer", Value: "127.0.0.1", IsJSONString: true}},
+		{"struct", testAnyPoint{X: 1, Y: 2}, Field{Name: "struct", Value: `{"X":1,"Y":2}`, TextValue: `"{X:1 Y:2 Tag:}"`}},
+		{"pointer", &testAnyPoint{X: 1, Y: 2, Tag: "a"}, Field{Name: "pointer", Value: `{"X":1,"Y":2,"tag":"a"}`, TextValue: `"&{X:1 Y:2 Tag:a}"`}},
+		{"map", map[string]int{"b": 2, "a": 1}, Field{Name: "map", Value: `{"a":1,"b":2}`, TextValue: `"map[a:1 b:2]"`}},
+		{"slice", []string{"a", "b c"}, Field{Name: "slice", Value: `["a","b c"]`, TextValue: `"[a b c]"`}},
+		{"nested", map[string]interface{}{"p": []testAnyPoint{{X: 1}}}, Field{Name: "nested", Value: `{"p":[{"X":1,"Y":0}]}`, TextValue: `"map[p:[{X:1 Y:0 Tag:}]]"`}},
+	}
+
+	for _, tc := range cases {
+		actual := Any(tc.Name, tc.Value).Field()
+		if actual != tc.Expected {
+			t.Errorf("%s:\nexpected: %#v\nactual:   %#v", tc.Name, tc.Expected, actual)
+		}
+	}
+}
+
+func Test_AnyCycle(t *testing.T) {
+	a := &testAnyNode{Name: "a"}
+	a.Next = &testAnyNode{Name: "b", Next: a}
+
+	f := JSONValue("cycle", a).Field()
+	if !f.IsJSONString {
+		t.Errorf("expected cycle to fall back to a string value")
+	}
+	if expected := "&{Name:a Next:{Name:b Next:<cycle>}}"; f.Value != expected {
+		t.Errorf("expected %q, got %q", expected, f.Value)
+	}
+
+	m := map[string]interface{}{}
+	m["self"] = m
+	f = JSONValue("cycle", m).Field()
+	if expected := "map[self:<cycle>]"; f.Value != expected {
+		t.Errorf("expected %q, got %q", expected, f.Value)
+	}
+}
+
+func Test_AnyHuge(t *testing.T) {
+	huge := make([]int, 10000)
+	f := JSONValue("huge", huge).Field()
+	if !f.IsJSONString {
+		t.Errorf("expected huge value to fall back to a string value")
+	}
+	if !strings.HasSuffix(f.Value, "...]") {
+		t.Errorf("expected huge value to be truncated, got %q", f.Value)
+	}
+
+	long := strings.Repeat("ʎ", anyMaxLen)
+	f = JSONValue("long", []string{long}).Field()
+	if len(f.Value) > anyMaxLen+len("...") || !strings.HasSuffix(f.Value, "ʎ...") {
+		t.Errorf("expected long value to be truncated on a rune boundary, got %q", f.Value)
+	}
+}
+
+func Test_AnyUnmarshalable(t *testing.T) {
+	f := JSONValue("fn", map[string]interface{}{"f": func() {}}).Field()
+	if !f.IsJSONString || f.Value != "map[f:<func()>]" {
+		t.Errorf("expected func to fall back to string value, got %#v", f)
+	}
+
+	// ensure the output is valid JSON
+	var sb strings.Builder
+	sb.WriteByte('{')
+	writeJSONField(&sb, f)
+	sb.WriteByte('}')
+	var target map[string]interface{}
+	if err := json.Unmarshal([]byte(sb.String()), &target); err != nil {
+		t.Errorf("expected valid JSON, got %v: %s", err, sb.String())
+	}
+}
+
+func Test_ErrorPanics(t *testing.T) {
+	nilErr := Field{Name: "err", Value: "<nil>", IsJSONString: true, Kind: KindError}
+	if f := Any("err", (*testNilError)(nil)).Field(); f != nilErr {
+		t.Errorf("expected nil pointer to be handled by Any, got %#v", f)
+	}
+	if f := (FieldError{Name: "err", Value: (*testNilError)(nil)}).Field(); f != nilErr {
+		t.Errorf("expected nil pointer to be handled by FieldError, got %#v", f)
+	}
+	if f := Any("err", testPanicError{}).Field(); f.Value != "<PANIC=boom>" {
+		t.Errorf("expected panic to be recovered, got %#v", f)
+	}
+	if f := ErrChainNamed("err", (*testNilError)(nil)).Field(); f.Value != `{"msg":"\u003cnil\u003e","type":"*frog.testNilError"}` {
+		t.Errorf("expected nil pointer to be handled by ErrChain, got %#v", f)
+	}
+}
+
+func Test_Stringer(t *testing.T) {
+	if f := Stringer("s", &testNilStringer{name: "ok"}).Field(); f.Value != "ok" || !f.IsJSONString {
+		t.Errorf("unexpected field: %#v", f)
+	}
+	if f := Stringer("s", (*testNilStringer)(nil)).Field(); f.Value != "<nil>" {
+		t.Errorf("expected nil pointer to be handled, got %#v", f)
+	}
+	if f := Stringer("s", nil).Field(); f.Value != "null" || f.IsJSONString {
+		t.Errorf("expected nil interface to be null, got %#v", f)
+	}
+}
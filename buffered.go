@@ -3,6 +3,7 @@ package frog
 import (
 	"fmt"
 	"io"
+	"strings"
 	"sync"
 	"sync/atomic"
 
@@ -11,11 +12,13 @@ import (
 )
 
 type Buffered struct {
-	minLevel Level
+	minLevel atomicLevel
+	levels   LevelRegistry
 	writer   io.Writer
 	prn      Printer
 	ch       chan bufmsg
 	wg       sync.WaitGroup
+	exitCode int // exit code after a Fatal line (never changes after creation)
 
 	isClosed    int32 // to keep thread safe, use atomic reads/writes/math
 	openAnchors int32 // to keep thread safe, use atomic reads/writes/math
@@ -27,6 +30,7 @@ const (
 	mtPrint      msgType = iota // string to print
 	mtAddLine                   // add an anchored line
 	mtRemoveLine                // remove an anchored line
+	mtFlush                     // close Done once everything before it has been written
 )
 
 type bufmsg struct {
@@ -34,15 +38,20 @@ type bufmsg struct {
 	Line  int32
 	Level Level
 	Msg   string
+	Done  chan struct{}
 }
 
-func NewBuffered(writer io.Writer, requestTerminalSize bool, prn Printer) *Buffered {
+// NewBuffered creates a root Logger that renders and writes lines on a separate goroutine, and that
+// supports anchored lines (if requestTerminalSize is true and the writer is a terminal).
+func NewBuffered(writer io.Writer, requestTerminalSize bool, prn Printer, opts ...RootOption) *Buffered {
+	cfg := newRootConfig(opts)
 	l := &Buffered{
-		minLevel: Info,
+		minLevel: atomicLevel{v: int32(Info)},
 		writer:   writer,
-		prn:      prn,
+		prn:      cfg.preparePrinter(prn),
 		ch:       make(chan bufmsg),
 		wg:       sync.WaitGroup{},
+		exitCode: cfg.fatalExitCode,
 	}
 
 	l.wg.Add(1)
@@ -83,6 +92,18 @@ func (l *Buffered) Close() {
 	l.wg.Wait()
 }
 
+// Flush waits until every line logged before it was called has been written, without closing the
+// Logger. Does nothing if the Logger is already closed.
+// Thread safe.
+func (l *Buffered) Flush() {
+	if atomic.LoadInt32(&l.isClosed) != 0 {
+		return
+	}
+	done := make(chan struct{})
+	l.ch <- bufmsg{Type: mtFlush, Done: done}
+	<-done
+}
+
 // AddAnchor creates a Logger that is "anchored" to the bottom of the output.
 // This "anchoring" is achieved by using ANSI to re-draw the anchored line at
 // the bottom as the output scrolls up.
@@ -161,7 +182,8 @@ func (l *Buffered) processor() {
 			if msg.Line <= 0 || msg.Level > Transient {
 				fmt.Fprint(l.writer, "\n")
 				fmt.Fprint(l.writer, ansi.PrevLine(1+len(anchoredLines)))
-				fmt.Fprintf(l.writer, "%s%s\n", msg.Msg, ansi.EraseEOL)
+				// the msg may span multiple lines (e.g. a stack trace), and each may be drawn over an anchored line
+				fmt.Fprintf(l.writer, "%s%s\n", strings.ReplaceAll(msg.Msg, "\n", ansi.EraseEOL+"\n"), ansi.EraseEOL)
 
 				for _, v := range anchoredLines {
 					fmt.Fprintf(l.writer, "%s%s\n", v.str, ansi.EraseEOL)
@@ -183,25 +205,39 @@ func (l *Buffered) processor() {
 			fmt.Fprint(l.writer, ansi.EraseEOL)
 			fmt.Fprint(l.writer, ansi.NextLine(offset))
 
+		case mtFlush:
+			close(msg.Done)
+
 		default:
 		}
 	}
 }
 
 func (l *Buffered) MinLevel() Level {
-	return l.minLevel
+	return l.minLevel.Load()
 }
 
 func (l *Buffered) SetMinLevel(level Level) Logger {
-	l.minLevel = level
+	l.minLevel.Store(level)
 	return l
 }
 
+// LevelRegistry returns the registry of min levels for named Loggers (see Named).
+func (l *Buffered) LevelRegistry() *LevelRegistry {
+	return &l.levels
+}
+
+func (l *Buffered) fatalExitCode() int {
+	return l.exitCode
+}
+
 func (l *Buffered) LogImpl(level Level, msg string, fielders []Fielder, opts []PrinterOption, d ImplData) {
-	d.MergeMinLevel(l.minLevel)
+	d.MergeMinLevel(l.levels.minLevel(d.LoggerName, l.minLevel.Load()))
 
 	keep := false
-	if d.AnchoredLine == 0 {
+	if d.ignoreMinLevel {
+		keep = true
+	} else if d.AnchoredLine == 0 {
 		keep = level >= d.MinLevel
 	} else {
 		keep = level >= d.MinLevel || level == Transient
@@ -213,7 +249,7 @@ func (l *Buffered) LogImpl(level Level, msg string, fielders []Fielder, opts []P
 	l.ch <- bufmsg{
 		Line:  d.AnchoredLine,
 		Level: level,
-		Msg:   l.prn.Render(level, opts, msg, FieldifyAndAppend(d.Fields, fielders)),
+		Msg:   l.prn.Render(level, opts, msg, d.AssembleFields(fielders)),
 	}
 }
 
@@ -222,6 +258,11 @@ func (l *Buffered) Transient(msg string, fielders ...Fielder) Logger {
 	return l
 }
 
+func (l *Buffered) Trace(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Trace, msg, fielders, nil, ImplData{})
+	return l
+}
+
 func (l *Buffered) Verbose(msg string, fielders ...Fielder) Logger {
 	l.LogImpl(Verbose, msg, fielders, nil, ImplData{})
 	return l
@@ -242,7 +283,16 @@ func (l *Buffered) Error(msg string, fielders ...Fielder) Logger {
 	return l
 }
 
+func (l *Buffered) Fatal(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Fatal, msg, fielders, nil, ImplData{})
+	fatalExit(l)
+	return l
+}
+
 func (l *Buffered) Log(level Level, msg string, fielders ...Fielder) Logger {
 	l.LogImpl(level, msg, fielders, nil, ImplData{})
+	if level == Fatal {
+		fatalExit(l)
+	}
 	return l
 }
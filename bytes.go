@@ -0,0 +1,163 @@
+package frog
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"time"
+)
+
+// BytesEncoding controls how a Bytes field encodes its value.
+type BytesEncoding byte
+
+const (
+	BytesEncodingHex BytesEncoding = iota
+	BytesEncodingBase64
+)
+
+// BytesDefaultLimit is the number of bytes a Bytes field includes before truncating, unless
+// changed via the field's WithLimit method.
+const BytesDefaultLimit = 64
+
+// Bytes adds a field with the passed in bytes encoded as a hex string. If there are more than
+// BytesDefaultLimit bytes (see WithLimit), the rest are left off, and the total length is noted
+// after the encoded bytes (e.g. "48656c6c6f...(1024 bytes)").
+func Bytes(name string, value []byte) FieldBytes {
+	return FieldBytes{Name: name, Value: value, Encoding: BytesEncodingHex, Limit: BytesDefaultLimit}
+}
+
+// BytesHex adds a field with the passed in bytes encoded as a hex string (the same as Bytes).
+func BytesHex(name string, value []byte) FieldBytes {
+	return FieldBytes{Name: name, Value: value, Encoding: BytesEncodingHex, Limit: BytesDefaultLimit}
+}
+
+// BytesBase64 adds a field with the passed in bytes encoded as a standard base64 string. See Bytes
+// for how long values are truncated.
+func BytesBase64(name string, value []byte) FieldBytes {
+	return FieldBytes{Name: name, Value: value, Encoding: BytesEncodingBase64, Limit: BytesDefaultLimit}
+}
+
+// ByteSize adds an integer field that holds a number of bytes. JSONPrinter renders the raw
+// integer, while text-based printers render a human readable size (e.g. "12.3 MiB").
+func ByteSize(name string, n int64) FieldByteSize {
+	return FieldByteSize{Name: name, Value: n}
+}
+
+// Rate adds a field for the throughput of transferring the passed in number of bytes over the
+// passed in duration. JSONPrinter renders the (rounded) integer number of bytes per second, while
+// text-based printers render a human readable rate (e.g. "12.3 MiB/s").
+// If the duration is not positive, the value is null.
+func Rate(name string, bytes int64, d time.Duration) FieldRate {
+	return FieldRate{Name: name, Bytes: bytes, Duration: d}
+}
+
+// Bytes
+
+type FieldBytes struct {
+	Name     string
+	Value    []byte
+	Encoding BytesEncoding
+	Limit    int // max number of bytes to encode (0 or less means no limit)
+}
+
+// WithLimit returns a copy of the field that encodes at most n bytes (or all of them if n <= 0).
+func (f FieldBytes) WithLimit(n int) FieldBytes {
+	f.Limit = n
+	return f
+}
+
+func (f FieldBytes) Field() Field {
+	if f.Value == nil {
+		return Field{Name: f.Name, Value: "null"}
+	}
+	b := f.Value
+	if f.Limit > 0 && len(b) > f.Limit {
+		b = b[:f.Limit]
+	}
+	var s string
+	switch f.Encoding {
+	case BytesEncodingBase64:
+		s = base64.StdEncoding.EncodeToString(b)
+	default:
+		s = hex.EncodeToString(b)
+	}
+	if len(b) < len(f.Value) {
+		s += "...(" + strconv.Itoa(len(f.Value)) + " bytes)"
+	}
+	return Field{Name: f.Name, Value: s, IsJSONString: true, IsJSONSafe: true}
+}
+
+// ByteSize
+
+type FieldByteSize struct {
+	Name  string
+	Value int64
+}
+
+func (f FieldByteSize) Field() Field {
+	return Field{
+		Name:      f.Name,
+		Value:     strconv.FormatInt(f.Value, 10),
+		TextValue: quoteIfNeeded(formatByteSize(float64(f.Value))),
+	}
+}
+
+// Rate
+
+type FieldRate struct {
+	Name     string
+	Bytes    int64
+	Duration time.Duration
+}
+
+func (f FieldRate) Field() Field {
+	if f.Duration <= 0 {
+		return Field{Name: f.Name, Value: "null"}
+	}
+	perSec := float64(f.Bytes) / f.Duration.Seconds()
+	return Field{
+		Name:      f.Name,
+		Value:     strconv.FormatInt(roundToInt64(perSec), 10),
+		TextValue: quoteIfNeeded(formatByteSize(perSec) + "/s"),
+	}
+}
+
+// roundToInt64 rounds to the nearest int64, clamping values that are out of range (e.g. a large
+// number of bytes over a few nanoseconds), as converting those is implementation-defined.
+func roundToInt64(f float64) int64 {
+	f = math.Round(f)
+	switch {
+	case f >= math.MaxInt64:
+		return math.MaxInt64
+	case f <= math.MinInt64:
+		return math.MinInt64
+	}
+	return int64(f)
+}
+
+var byteSizeUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// formatByteSize renders a number of bytes using binary (1024-based) units, with one decimal
+// place for anything bigger than 1 KiB (e.g. "512 B", "1.5 KiB", "12.3 MiB").
+func formatByteSize(n float64) string {
+	sign := ""
+	if n < 0 {
+		sign = "-"
+		n = -n
+	}
+	if n < 1024 {
+		return sign + strconv.FormatFloat(n, 'f', 0, 64) + " B"
+	}
+	unit := -1
+	for n >= 1024 && unit < len(byteSizeUnits)-1 {
+		n /= 1024
+		unit++
+	}
+	// rounding to one decimal place could push us over to the next unit (e.g. 1023.96 KiB)
+	if n >= 1023.95 && unit < len(byteSizeUnits)-1 {
+		n /= 1024
+		unit++
+	}
+	return sign + strconv.FormatFloat(n, 'f', 1, 64) + " " + byteSizeUnits[unit]
+}
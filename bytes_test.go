@@ -0,0 +1,57 @@
+package frog
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_BytesFields(t *testing.T) {
+	long := bytes.Repeat([]byte{0xab}, 100)
+	cases := []struct {
+		Name         string
+		Fielder      Fielder
+		ExpectedText string
+		ExpectedJSON string
+	}{
+		{"hex", Bytes("b", []byte("Hi!")), `b=486921`, `"b":"486921"`},
+		{"base64", BytesBase64("b", []byte("Hi!")), `b=SGkh`, `"b":"SGkh"`},
+		{"nil", BytesHex("b", nil), `b=null`, `"b":null`},
+		{"truncated", BytesHex("b", long).WithLimit(4), `b="abababab...(100 bytes)"`, `"b":"abababab...(100 bytes)"`},
+		{"default limit", Bytes("b", long), `b="` + strings.Repeat("ab", BytesDefaultLimit) + `...(100 bytes)"`, `"b":"` + strings.Repeat("ab", BytesDefaultLimit) + `...(100 bytes)"`},
+		{"size", ByteSize("n", 12*1024*1024+300*1024), `n="12.3 MiB"`, `"n":12890112`},
+		{"small size", ByteSize("n", 512), `n="512 B"`, `"n":512`},
+		{"rate", Rate("r", 3*1024*1024, 2*time.Second), `r="1.5 MiB/s"`, `"r":1572864`},
+		{"zero rate", Rate("r", 1024, 0), `r=null`, `"r":null`},
+		{"huge rate", Rate("r", math.MaxInt64, time.Nanosecond), `r="8000000000.0 EiB/s"`, `"r":9223372036854775807`},
+		{"huge negative rate", Rate("r", math.MinInt64, time.Nanosecond), `r="-8000000000.0 EiB/s"`, `"r":-9223372036854775808`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			testFieldOutput(t, tc.Fielder, tc.ExpectedText, tc.ExpectedJSON)
+		})
+	}
+}
+
+func Test_FormatByteSize(t *testing.T) {
+	cases := []struct {
+		Bytes    float64
+		Expected string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{1024*1024 - 1, "1.0 MiB"},
+		{-2048, "-2.0 KiB"},
+		{1 << 62, "4.0 EiB"},
+	}
+	for _, tc := range cases {
+		if actual := formatByteSize(tc.Bytes); actual != tc.Expected {
+			t.Errorf("formatByteSize(%v): expected %q, got %q", tc.Bytes, tc.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package frog
+
+import (
+	"path"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// CallerPath controls how much of a caller's file path is displayed.
+type CallerPath byte
+
+const (
+	CallerPathShort CallerPath = iota // parent directory and file name, e.g. "frog/printer.go"
+	CallerPathBase                    // just the file name, e.g. "printer.go"
+	CallerPathFull                    // the full path, as recorded by the compiler
+)
+
+// Caller holds information about where a log line came from.
+type Caller struct {
+	File     string // path to the file, formatted as specified by the CallerPath printer option
+	Line     int
+	Function string // fully qualified function name, e.g. "github.com/danbrakeley/frog.New"
+}
+
+// FileLine returns the file and line, separated by a colon (e.g. "frog/printer.go:123").
+func (c Caller) FileLine() string {
+	return c.File + ":" + strconv.Itoa(c.Line)
+}
+
+// frogPkgPrefix is the prefix of every function name in this package (e.g. "github.com/danbrakeley/frog.")
+var frogPkgPrefix = func() string {
+	name := runtime.FuncForPC(reflect.ValueOf(trimCallerPath).Pointer()).Name()
+	return name[:strings.LastIndex(name, ".")+1]
+}()
+
+// isFrogFrame returns true if the frame is part of frog itself, which includes any logging
+// methods, nested loggers, and printers, but excludes this package's tests.
+func isFrogFrame(f runtime.Frame) bool {
+	return strings.HasPrefix(f.Function, frogPkgPrefix) && !strings.HasSuffix(f.File, "_test.go")
+}
+
+// captureCaller walks up the stack, past any frog frames, then past the requested number of
+// additional frames, and returns the resulting Caller.
+// This only works when called from the goroutine that called the Logger method, so it must be
+// called during LogImpl or Render.
+func captureCaller(skip int, style CallerPath) (Caller, bool) {
+	var pcs [64]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		f, more := frames.Next()
+		if !isFrogFrame(f) {
+			if skip <= 0 {
+				return Caller{File: trimCallerPath(f.File, style), Line: f.Line, Function: f.Function}, true
+			}
+			skip--
+		}
+		if !more {
+			break
+		}
+	}
+	return Caller{}, false
+}
+
+func trimCallerPath(file string, style CallerPath) string {
+	switch style {
+	case CallerPathFull:
+		return file
+	case CallerPathBase:
+		return path.Base(file)
+	}
+	// runtime always uses forward slashes, regardless of OS
+	idx := strings.LastIndexByte(file, '/')
+	if idx == -1 {
+		return file
+	}
+	idx = strings.LastIndexByte(file[:idx], '/')
+	if idx == -1 {
+		return file
+	}
+	return file[idx+1:]
+}
+
+// captureStack walks up the stack, past any frog frames, then past the requested number of
+// additional frames, and returns the remaining frames formatted similarly to a Go stack trace.
+// Like captureCaller, it must be called from the goroutine that called the Logger method.
+// It is a var so that tests can replace it with a predictable stack.
+var captureStack = func(skip int) []string {
+	var pcs [128]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var lines []string
+	inFrog := true
+	for {
+		f, more := frames.Next()
+		if inFrog && isFrogFrame(f) {
+			// only skip frog's frames at the top of the stack
+		} else if skip > 0 {
+			inFrog = false
+			skip--
+		} else if f.Function != "runtime.goexit" {
+			inFrog = false
+			lines = append(lines, f.Function, "    "+f.File+":"+strconv.Itoa(f.Line))
+		}
+		if !more {
+			break
+		}
+	}
+	return lines
+}
@@ -0,0 +1,160 @@
+package frog
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// lineHere returns the line number of the code that called it
+func lineHere() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func Test_CallerThroughNestedLoggers(t *testing.T) {
+	var buf1, buf2 bytes.Buffer
+	prn := TextPrinter{}
+	root := NewBuffered(&buf1, false, prn.SetOptions(POCaller(true), POCallerPath(CallerPathBase)))
+	tee, close := NewRootTee(root, NewUnbuffered(&buf2, &TextPrinter{printCaller: true}))
+
+	anchored := AddAnchor(WithFields(tee, String("a", "b")))
+	log := WithOptions(anchored, POLevel(false))
+
+	line := lineHere() + 1
+	log.Info("nested")
+	close()
+
+	expected := "a=b   caller_test.go:" + strconv.Itoa(line)
+	if actual := buf1.String(); !strings.Contains(actual, expected) {
+		t.Errorf("expected line to contain %q, got %q", expected, actual)
+	}
+	_, file, _, _ := runtime.Caller(0)
+	expected = "a=b   " + trimCallerPath(file, CallerPathShort) + ":" + strconv.Itoa(line)
+	if actual := strings.TrimSpace(buf2.String()); !strings.HasSuffix(actual, expected) {
+		t.Errorf("expected line to end with %q, got %q", expected, actual)
+	}
+}
+
+func Test_CallerJSON(t *testing.T) {
+	var buf bytes.Buffer
+	prn := JSONPrinter{}
+	log := NewUnbuffered(&buf, prn.SetOptions(POCaller(true), POCallerPath(CallerPathFull)))
+	line := lineHere() + 1
+	WithFields(log, Int("n", 1)).Warning("nested")
+	log.Close()
+
+	var target map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &target); err != nil {
+		t.Fatalf("error parsing logged json: %v\n%s", err, buf.String())
+	}
+	_, file, _, _ := runtime.Caller(0)
+	if expected := file + ":" + strconv.Itoa(line); target["caller"] != expected {
+		t.Errorf("expected caller %q, got %q", expected, target["caller"])
+	}
+	if expected := frogPkgPrefix + "Test_CallerJSON"; target["caller_func"] != expected {
+		t.Errorf("expected caller_func %q, got %q", expected, target["caller_func"])
+	}
+}
+
+func Test_CallerSkip(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewUnbuffered(&buf, &TextPrinter{printCaller: true, callerPath: CallerPathBase, callerSkip: 1})
+	helper := func(msg string) {
+		log.Info(msg)
+	}
+	line := lineHere() + 1
+	helper("via helper")
+
+	expected := "caller_test.go:" + strconv.Itoa(line)
+	if actual := strings.TrimSpace(buf.String()); !strings.HasSuffix(actual, expected) {
+		t.Errorf("expected line to end with %q, got %q", expected, actual)
+	}
+}
+
+func Test_CallerTemplate(t *testing.T) {
+	prn, err := NewTemplatePrinter("{caller} {caller:func}: {msg}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	log := NewUnbuffered(&buf, prn.SetOptions(POCallerPath(CallerPathBase)))
+	line := lineHere() + 1
+	log.Info("hello")
+
+	expected := "caller_test.go:" + strconv.Itoa(line) + " " + frogPkgPrefix + "Test_CallerTemplate: hello\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+
+	buf.Reset()
+	WithOptions(log, POCaller(false)).Info("hidden")
+	if actual := buf.String(); actual != " : hidden\n" {
+		t.Errorf("expected caller to be hidden, got %q", actual)
+	}
+}
+
+func Test_TrimCallerPath(t *testing.T) {
+	cases := []struct {
+		File     string
+		Style    CallerPath
+		Expected string
+	}{
+		{"/home/user/go/src/frog/printer.go", CallerPathShort, "frog/printer.go"},
+		{"/home/user/go/src/frog/printer.go", CallerPathBase, "printer.go"},
+		{"/home/user/go/src/frog/printer.go", CallerPathFull, "/home/user/go/src/frog/printer.go"},
+		{"C:/src/frog/printer.go", CallerPathShort, "frog/printer.go"},
+		{"frog/printer.go", CallerPathShort, "frog/printer.go"},
+		{"printer.go", CallerPathShort, "printer.go"},
+	}
+	for _, tc := range cases {
+		if actual := trimCallerPath(tc.File, tc.Style); actual != tc.Expected {
+			t.Errorf("trimCallerPath(%q, %d): expected %q, got %q", tc.File, tc.Style, tc.Expected, actual)
+		}
+	}
+}
+
+func Test_StackTrace(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewUnbuffered(&buf, &TextPrinter{printLevel: true, printStack: true})
+	log.Warning("no stack for warnings")
+	log.Error("stack for errors", Int("n", 1))
+	log.Close()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) < 4 {
+		t.Fatalf("expected at least 4 lines, got:\n%s", buf.String())
+	}
+	if lines[0] != "[WRN] no stack for warnings" {
+		t.Errorf("unexpected warning line: %q", lines[0])
+	}
+	if lines[1] != "[ERR] stack for errors    n=1" {
+		t.Errorf("unexpected error line: %q", lines[1])
+	}
+	if expected := "    " + frogPkgPrefix + "Test_StackTrace"; lines[2] != expected {
+		t.Errorf("expected first frame to be %q, got %q", expected, lines[2])
+	}
+	if !strings.HasPrefix(lines[3], "        ") || !strings.Contains(lines[3], "caller_test.go:") {
+		t.Errorf("expected second line of stack to be the indented file and line, got %q", lines[3])
+	}
+}
+
+func Test_StackTraceJSON(t *testing.T) {
+	var buf bytes.Buffer
+	prn := JSONPrinter{}
+	log := NewUnbuffered(&buf, prn.SetOptions(POStackTrace(true)))
+	log.Error("stack for errors")
+	log.Close()
+
+	var target map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &target); err != nil {
+		t.Fatalf("error parsing logged json: %v\n%s", err, buf.String())
+	}
+	stack, _ := target["stack"].(string)
+	if expected := frogPkgPrefix + "Test_StackTraceJSON\n"; !strings.HasPrefix(stack, expected) {
+		t.Errorf("expected stack to start with %q, got %q", expected, stack)
+	}
+}
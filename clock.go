@@ -0,0 +1,74 @@
+package frog
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of the current time for Printers (and anything else in frog that needs to
+// know what time it is). Replacing the Clock allows for deterministic output, e.g. in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the default Clock, which just calls time.Now().
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// fixedClock always returns the same time. It is used when a line has to be rendered more than
+// once, so that each attempt has the same timestamp.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// ManualClock is a Clock whose time only changes when told to.
+// Thread safe.
+type ManualClock struct {
+	mutex   sync.Mutex
+	t       time.Time
+	advance time.Duration
+}
+
+// NewManualClock creates a ManualClock that is set to the passed in time.
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{t: t}
+}
+
+// Now returns the clock's current time, and then moves the clock forward by the auto advance
+// duration (if any).
+func (c *ManualClock) Now() time.Time {
+	c.mutex.Lock()
+	t := c.t
+	c.t = c.t.Add(c.advance)
+	c.mutex.Unlock()
+	return t
+}
+
+// Set changes the clock's current time.
+func (c *ManualClock) Set(t time.Time) {
+	c.mutex.Lock()
+	c.t = t
+	c.mutex.Unlock()
+}
+
+// Add moves the clock's current time forward by the passed in duration.
+func (c *ManualClock) Add(d time.Duration) {
+	c.mutex.Lock()
+	c.t = c.t.Add(d)
+	c.mutex.Unlock()
+}
+
+// SetAutoAdvance sets a duration that the clock will move forward after each call to Now.
+// This is useful for simulating the passage of time between log lines.
+func (c *ManualClock) SetAutoAdvance(d time.Duration) {
+	c.mutex.Lock()
+	c.advance = d
+	c.mutex.Unlock()
+}
@@ -0,0 +1,109 @@
+package frog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func Test_ClockInterfaces(t *testing.T) {
+	var _ Clock = SystemClock
+	var _ Clock = &ManualClock{}
+}
+
+func Test_ManualClock(t *testing.T) {
+	start := time.Date(2023, 4, 21, 3, 49, 13, 0, time.UTC)
+	c := NewManualClock(start)
+	if !c.Now().Equal(start) || !c.Now().Equal(start) {
+		t.Errorf("expected time to stand still")
+	}
+	c.Add(time.Second)
+	if expected := start.Add(time.Second); !c.Now().Equal(expected) {
+		t.Errorf("expected %v after Add", expected)
+	}
+	c.SetAutoAdvance(time.Millisecond)
+	c.Set(start)
+	if !c.Now().Equal(start) {
+		t.Errorf("expected %v after Set", start)
+	}
+	if expected := start.Add(time.Millisecond); !c.Now().Equal(expected) {
+		t.Errorf("expected %v after auto advance", expected)
+	}
+}
+
+func Test_ClockPrinters(t *testing.T) {
+	fnClock := func() Clock {
+		c := NewManualClock(time.Date(2019, 9, 10, 21, 44, 0, 0, time.UTC))
+		c.SetAutoAdvance(1500 * time.Millisecond)
+		return c
+	}
+
+	t.Run("clock.buf", func(t *testing.T) {
+		var buf bytes.Buffer
+		prn := TextPrinter{printTime: true, printLevel: true}
+		log := NewBuffered(&buf, false, prn.SetOptions(POClock(fnClock()), POTimePrecision(time.Millisecond)))
+		addAndRemoveAnchors(log)
+		log.Close()
+		AssertGolden(t, "clock.buf", buf.Bytes())
+	})
+	t.Run("clock.json", func(t *testing.T) {
+		var buf bytes.Buffer
+		prn := JSONPrinter{}
+		log := NewUnbuffered(&buf, prn.SetOptions(POClock(fnClock())))
+		addAndRemoveAnchors(log)
+		log.Close()
+		AssertGolden(t, "clock.json", buf.Bytes())
+	})
+}
+
+func Test_RootClock(t *testing.T) {
+	clock := NewManualClock(time.Date(2019, 9, 10, 21, 44, 0, 0, time.UTC))
+
+	// elapsed time is measured from when the root was created, using the root's clock
+	var buf bytes.Buffer
+	prn := TextPrinter{printTime: true, printLevel: true}
+	log := NewBuffered(&buf, false, prn.SetOptions(POTimeElapsed(true)), ROClock(clock))
+	clock.Add(90 * time.Second)
+	log.Info("elapsed")
+	log.Close()
+	if expected := "00:01:30 [nfo] elapsed\n"; buf.String() != expected {
+		t.Errorf("text:\nexpected: %q\nactual:   %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	NewUnbuffered(&buf, &JSONPrinter{}, ROClock(clock)).Info("json")
+	if expected := `{"timestamp":"2019-09-10T21:45:30Z","level":"info","msg":"json"}` + "\n"; buf.String() != expected {
+		t.Errorf("json:\nexpected: %s\nactual:   %s", expected, buf.String())
+	}
+}
+
+func Test_RootsSharingAPrinter(t *testing.T) {
+	clockA := NewManualClock(time.Date(2019, 9, 10, 21, 44, 0, 0, time.UTC))
+	clockB := NewManualClock(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	// each root sets its options on its own copy of the printer, so neither sees the other's clock
+	prn := &JSONPrinter{}
+	var bufA, bufB bytes.Buffer
+	logA := NewUnbuffered(&bufA, prn, ROClock(clockA))
+	logB := NewUnbuffered(&bufB, prn, ROClock(clockB))
+	logA.Info("a")
+	logB.Info("b")
+	if expected := `{"timestamp":"2019-09-10T21:44:00Z","level":"info","msg":"a"}` + "\n"; bufA.String() != expected {
+		t.Errorf("root a:\nexpected: %s\nactual:   %s", expected, bufA.String())
+	}
+	if expected := `{"timestamp":"2020-01-02T03:04:05Z","level":"info","msg":"b"}` + "\n"; bufB.String() != expected {
+		t.Errorf("root b:\nexpected: %s\nactual:   %s", expected, bufB.String())
+	}
+	if prn.clock != nil {
+		t.Errorf("expected the passed in printer to be left alone, but its clock was set")
+	}
+}
+
+func Test_JSONPrinterTimeOverride(t *testing.T) {
+	var buf bytes.Buffer
+	prn := JSONPrinter{TimeOverride: time.Date(2019, 9, 10, 21, 44, 0, 0, time.UTC)}
+	NewUnbuffered(&buf, &prn).Info("override")
+	if expected := `{"timestamp":"2019-09-10T21:44:00Z","level":"info","msg":"override"}` + "\n"; buf.String() != expected {
+		t.Errorf("\nexpected: %s\nactual:   %s", expected, buf.String())
+	}
+}
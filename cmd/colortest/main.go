@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/danbrakeley/ansi"
+	"github.com/danbrakeley/frog"
 )
 
 func main() {
@@ -85,4 +86,87 @@ func main() {
 		"value",
 		ansi.Reset,
 	}, ""))
+
+	depth := frog.DetectColorDepth()
+	depthNames := []string{"16 colors", "256 colors", "24-bit color"}
+	fmt.Println("")
+	fmt.Printf("Detected color depth: %s (from COLORTERM and TERM)\n", depthNames[depth])
+
+	fmt.Println("")
+	fmt.Println("Styles:")
+	fmt.Println(strings.Join([]string{
+		frog.White.Bold().ANSI(depth) + "Bold" + ansi.Reset,
+		frog.White.Dim().ANSI(depth) + "Dim" + ansi.Reset,
+		frog.White.Italic().ANSI(depth) + "Italic" + ansi.Reset,
+		frog.White.Underline().ANSI(depth) + "Underline" + ansi.Reset,
+		frog.White.Bg(frog.DarkRed).ANSI(depth) + "Background" + ansi.Reset,
+	}, " "))
+
+	// for each section, show the colors as they are, and then as they would be downgraded
+	for _, d := range []frog.ColorDepth{frog.ColorDepthTrueColor, frog.ColorDepth256, frog.ColorDepth16} {
+		if d > depth {
+			continue
+		}
+		fmt.Println("")
+		fmt.Printf("256 colors (as %s):\n", depthNames[d])
+		for row := 0; row < 16; row++ {
+			var sb strings.Builder
+			for col := 0; col < 16; col++ {
+				sb.WriteString(frog.DefaultColor.Bg(frog.Color256(uint8(row*16 + col))).ANSI(d))
+				sb.WriteString("  ")
+			}
+			sb.WriteString(ansi.Reset)
+			fmt.Println(sb.String())
+		}
+
+		fmt.Println("")
+		fmt.Printf("24-bit colors (as %s):\n", depthNames[d])
+		for row := 0; row < 4; row++ {
+			var sb strings.Builder
+			for col := 0; col < 64; col++ {
+				hue := float64(col) / 64
+				r, g, b := hueToRGB(hue, 1-float64(row)/4)
+				sb.WriteString(frog.DefaultColor.Bg(frog.RGB(r, g, b)).ANSI(d))
+				sb.WriteString(" ")
+			}
+			sb.WriteString(ansi.Reset)
+			fmt.Println(sb.String())
+		}
+	}
+}
+
+// hueToRGB converts a hue (0 to 1) at full saturation, and the given brightness (0 to 1), to RGB.
+func hueToRGB(hue, brightness float64) (uint8, uint8, uint8) {
+	h := hue * 6
+	x := 1 - abs(mod2(h)-1)
+	var r, g, b float64
+	switch int(h) {
+	case 0:
+		r, g, b = 1, x, 0
+	case 1:
+		r, g, b = x, 1, 0
+	case 2:
+		r, g, b = 0, 1, x
+	case 3:
+		r, g, b = 0, x, 1
+	case 4:
+		r, g, b = x, 0, 1
+	default:
+		r, g, b = 1, 0, x
+	}
+	return uint8(r * brightness * 255), uint8(g * brightness * 255), uint8(b * brightness * 255)
+}
+
+func mod2(v float64) float64 {
+	for v >= 2 {
+		v -= 2
+	}
+	return v
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
 }
@@ -57,6 +57,7 @@ func main() {
 
 	log.SetMinLevel(frog.Transient)
 	log.Transient("transient line")
+	log.Trace("trace line")
 	log.Verbose("verbose line")
 	log.Info("info line")
 	log.Warning("warning line")
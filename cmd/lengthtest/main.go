@@ -27,11 +27,13 @@ func main() {
 		n := i
 		anchored := frog.AddAnchor(log)
 		dark := frog.WithOptions(anchored, frog.POPalette(frog.Palette{
-			{frog.DarkCyan, frog.DarkGray}, // Transient
-			{frog.DarkCyan, frog.DarkGray}, // Verbose
-			{frog.DarkCyan, frog.DarkGray}, // Info
-			{frog.DarkCyan, frog.DarkGray}, // Warning
-			{frog.DarkCyan, frog.DarkGray}, // Error
+			frog.Transient: {frog.DarkCyan, frog.DarkGray},
+			frog.Trace:     {frog.DarkCyan, frog.DarkGray},
+			frog.Verbose:   {frog.DarkCyan, frog.DarkGray},
+			frog.Info:      {frog.DarkCyan, frog.DarkGray},
+			frog.Warning:   {frog.DarkCyan, frog.DarkGray},
+			frog.Error:     {frog.DarkCyan, frog.DarkGray},
+			frog.Fatal:     {frog.DarkCyan, frog.DarkGray},
 		}))
 		go func() {
 			runProcess(dark, n)
@@ -0,0 +1,292 @@
+package frog
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Color is a foreground color, along with an optional background color and text styles.
+// The 16 basic colors are constants (e.g. Red, DarkBlue), while 256-color and 24-bit colors can be
+// made with Color256 and RGB. Backgrounds and styles are added via methods, e.g.:
+//
+//	frog.Yellow.Bold()
+//	frog.White.Bg(frog.DarkRed)
+//	frog.RGB(255, 128, 0).Underline()
+//
+// When the terminal doesn't support 256 or 24-bit colors (see DetectColorDepth), the nearest
+// supported color is used instead.
+type Color uint64
+
+const (
+	Black Color = iota
+	DarkRed
+	DarkGreen
+	DarkYellow
+	DarkBlue
+	DarkMagenta
+	DarkCyan
+	LightGray
+	DarkGray
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	White
+)
+
+// DefaultColor leaves the terminal's default foreground color as-is. It is meant to be combined
+// with a background or styles (e.g. DefaultColor.Bold()).
+const DefaultColor = Color(colorModeNone) << colorFgModeShift
+
+// A Color is packed into bits as follows:
+//
+//	 0-23: foreground value (basic index, 256-color index, or 24-bit RGB)
+//	24-25: foreground mode (basic, 256, RGB, or none)
+//	26-49: background value
+//	50-51: background mode (none, basic, 256, or RGB)
+//	52-55: styles (bold, dim, italic, underline)
+//
+// Note that the basic foreground mode is 0, so that Black through White are just 0 through 15.
+
+const (
+	colorValueMask   = 0xffffff
+	colorModeMask    = 0x3
+	colorFgModeShift = 24
+	colorBgShift     = 26
+	colorBgModeShift = 50
+	colorStyleShift  = 52
+)
+
+// foreground modes
+const (
+	colorModeBasic = 0
+	colorMode256   = 1
+	colorModeRGB   = 2
+	colorModeNone  = 3
+)
+
+// the background modes are shifted by one from the foreground modes, so that 0 means no background
+const bgModeNone = 0
+
+const (
+	styleBold Color = 1 << (colorStyleShift + iota)
+	styleDim
+	styleItalic
+	styleUnderline
+)
+
+// Color256 returns one of the 256 indexed colors (0-15 are the basic colors, 16-231 are a 6x6x6
+// color cube, and 232-255 are a grayscale ramp).
+func Color256(index uint8) Color {
+	return Color(index) | colorMode256<<colorFgModeShift
+}
+
+// RGB returns a 24-bit "true" color.
+func RGB(r, g, b uint8) Color {
+	return Color(uint32(r)<<16|uint32(g)<<8|uint32(b)) | colorModeRGB<<colorFgModeShift
+}
+
+// Bg returns a copy of c with the passed in color's foreground used as the background color.
+func (c Color) Bg(bg Color) Color {
+	c &^= (colorValueMask << colorBgShift) | (colorModeMask << colorBgModeShift)
+	mode := bg.fgMode()
+	if mode == colorModeNone {
+		return c
+	}
+	return c | (bg&colorValueMask)<<colorBgShift | Color(mode+1)<<colorBgModeShift
+}
+
+// Bold returns a copy of c that also uses bold text.
+func (c Color) Bold() Color { return c | styleBold }
+
+// Dim returns a copy of c that also uses dim (faint) text.
+func (c Color) Dim() Color { return c | styleDim }
+
+// Italic returns a copy of c that also uses italic text.
+func (c Color) Italic() Color { return c | styleItalic }
+
+// Underline returns a copy of c that also uses underlined text.
+func (c Color) Underline() Color { return c | styleUnderline }
+
+func (c Color) fgMode() int   { return int(c>>colorFgModeShift) & colorModeMask }
+func (c Color) fgValue() int  { return int(c & colorValueMask) }
+func (c Color) bgMode() int   { return int(c>>colorBgModeShift) & colorModeMask }
+func (c Color) bgValue() int  { return int(c>>colorBgShift) & colorValueMask }
+func (c Color) styles() Color { return c & (0xf << colorStyleShift) }
+
+// isPlain returns true if the color is just one of the 16 basic foreground colors
+func (c Color) isPlain() bool {
+	return c.fgMode() == colorModeBasic && c.fgValue() < 16 && c.bgMode() == bgModeNone && c.styles() == 0
+}
+
+// ColorDepth is how many colors a terminal supports.
+type ColorDepth byte
+
+const (
+	ColorDepth16 ColorDepth = iota
+	ColorDepth256
+	ColorDepthTrueColor
+)
+
+// DetectColorDepth guesses the terminal's color depth from the COLORTERM and TERM environment
+// variables. If it can't tell, it assumes only the 16 basic colors are supported.
+func DetectColorDepth() ColorDepth {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ColorDepthTrueColor
+	}
+	term := strings.ToLower(os.Getenv("TERM"))
+	switch {
+	case strings.Contains(term, "truecolor"), strings.Contains(term, "24bit"), strings.Contains(term, "direct"):
+		return ColorDepthTrueColor
+	case strings.Contains(term, "256"):
+		return ColorDepth256
+	}
+	return ColorDepth16
+}
+
+// ANSI returns the escape sequence that sets the color, downgraded as needed to fit the passed in
+// color depth.
+func (c Color) ANSI(depth ColorDepth) string {
+	return c.ansi(depth, false)
+}
+
+// ansi returns the escape sequence that sets the color, downgraded to fit the passed in depth.
+// Plain colors (i.e. just a basic foreground color) use the short sequences from the ansi package
+// (unless reset is true), while anything else first resets any previous color and styles.
+func (c Color) ansi(depth ColorDepth, reset bool) string {
+	if c.isPlain() && !reset {
+		return ansiFgColor(c)
+	}
+	params := []string{"0"}
+	if c&styleBold != 0 {
+		params = append(params, "1")
+	}
+	if c&styleDim != 0 {
+		params = append(params, "2")
+	}
+	if c&styleItalic != 0 {
+		params = append(params, "3")
+	}
+	if c&styleUnderline != 0 {
+		params = append(params, "4")
+	}
+	if mode := c.fgMode(); mode != colorModeNone {
+		params = append(params, colorParams(mode, c.fgValue(), depth, false)...)
+	}
+	if mode := c.bgMode(); mode != bgModeNone {
+		params = append(params, colorParams(mode-1, c.bgValue(), depth, true)...)
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// colorParams returns the SGR parameters for a color in the given (foreground) mode.
+func colorParams(mode, value int, depth ColorDepth, bg bool) []string {
+	if mode == colorModeRGB && depth < ColorDepthTrueColor {
+		mode, value = colorMode256, rgbTo256((value>>16)&0xff, (value>>8)&0xff, value&0xff)
+	}
+	if mode == colorMode256 && depth < ColorDepth256 {
+		mode, value = colorModeBasic, color256ToBasic(value)
+	}
+
+	switch mode {
+	case colorModeBasic:
+		value &= 0xf
+		base := 30
+		if value >= 8 {
+			base = 90 - 8
+		}
+		if bg {
+			base += 10
+		}
+		return []string{strconv.Itoa(base + value)}
+	case colorMode256:
+		if bg {
+			return []string{"48", "5", strconv.Itoa(value)}
+		}
+		return []string{"38", "5", strconv.Itoa(value)}
+	default:
+		r, g, b := strconv.Itoa((value>>16)&0xff), strconv.Itoa((value>>8)&0xff), strconv.Itoa(value&0xff)
+		if bg {
+			return []string{"48", "2", r, g, b}
+		}
+		return []string{"38", "2", r, g, b}
+	}
+}
+
+// cubeLevels are the values of each of the 6 steps in the 256-color palette's color cube
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// basicRGB are typical RGB values for the 16 basic colors
+var basicRGB = [16][3]int{
+	{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0}, {0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+	{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// rgbTo256 returns the index of the nearest color in the 256-color palette's color cube or
+// grayscale ramp.
+func rgbTo256(r, g, b int) int {
+	nearestLevel := func(v int) int {
+		best := 0
+		for i, level := range cubeLevels {
+			if abs(level-v) < abs(cubeLevels[best]-v) {
+				best = i
+			}
+		}
+		return best
+	}
+	ri, gi, bi := nearestLevel(r), nearestLevel(g), nearestLevel(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDist := colorDist(r, g, b, cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	// the grayscale ramp goes from 8 to 238 in steps of 10
+	gray := (r + g + b) / 3
+	grayIndex := (gray - 8 + 5) / 10
+	if grayIndex < 0 {
+		grayIndex = 0
+	} else if grayIndex > 23 {
+		grayIndex = 23
+	}
+	grayLevel := 8 + grayIndex*10
+	if colorDist(r, g, b, grayLevel, grayLevel, grayLevel) < cubeDist {
+		return 232 + grayIndex
+	}
+	return cube
+}
+
+// color256ToBasic returns the index of the nearest basic color to the passed in 256-color index.
+func color256ToBasic(index int) int {
+	if index < 16 {
+		return index
+	}
+	var r, g, b int
+	if index >= 232 {
+		r = 8 + (index-232)*10
+		g, b = r, r
+	} else {
+		i := index - 16
+		r, g, b = cubeLevels[i/36], cubeLevels[(i/6)%6], cubeLevels[i%6]
+	}
+	best, bestDist := 0, -1
+	for i, c := range basicRGB {
+		if d := colorDist(r, g, b, c[0], c[1], c[2]); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+func colorDist(r1, g1, b1, r2, g2, b2 int) int {
+	dr, dg, db := r1-r2, g1-g2, b1-b2
+	return dr*dr + dg*dg + db*db
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,93 @@
+package frog
+
+import (
+	"os"
+	"testing"
+
+	"github.com/danbrakeley/ansi"
+)
+
+func Test_BasicColorsUnchanged(t *testing.T) {
+	expected := []string{
+		ansi.FgBlack, ansi.FgDarkRed, ansi.FgDarkGreen, ansi.FgDarkYellow,
+		ansi.FgDarkBlue, ansi.FgDarkMagenta, ansi.FgDarkCyan, ansi.FgLightGray,
+		ansi.FgDarkGray, ansi.FgRed, ansi.FgGreen, ansi.FgYellow,
+		ansi.FgBlue, ansi.FgMagenta, ansi.FgCyan, ansi.FgWhite,
+	}
+	for i, seq := range expected {
+		for _, depth := range []ColorDepth{ColorDepth16, ColorDepth256, ColorDepthTrueColor} {
+			if actual := Color(i).ANSI(depth); actual != seq {
+				t.Errorf("color %d at depth %d: expected %q, got %q", i, depth, seq, actual)
+			}
+		}
+	}
+}
+
+func Test_ColorANSI(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Color    Color
+		Depth    ColorDepth
+		Expected string
+	}{
+		{"bold", Yellow.Bold(), ColorDepth16, "\x1b[0;1;93m"},
+		{"all styles", DarkRed.Bold().Dim().Italic().Underline(), ColorDepth16, "\x1b[0;1;2;3;4;31m"},
+		{"background", White.Bg(DarkRed), ColorDepth16, "\x1b[0;97;41m"},
+		{"bright background", Black.Bg(Cyan), ColorDepth16, "\x1b[0;30;106m"},
+		{"default fg", DefaultColor.Underline(), ColorDepth16, "\x1b[0;4m"},
+		{"256", Color256(208), ColorDepth256, "\x1b[0;38;5;208m"},
+		{"256 bg", Black.Bg(Color256(208)), ColorDepthTrueColor, "\x1b[0;30;48;5;208m"},
+		{"256 to 16", Color256(196), ColorDepth16, "\x1b[0;91m"},
+		{"256 gray to 16", Color256(244), ColorDepth16, "\x1b[0;90m"},
+		{"rgb", RGB(255, 128, 0), ColorDepthTrueColor, "\x1b[0;38;2;255;128;0m"},
+		{"rgb bg", DefaultColor.Bg(RGB(1, 2, 3)), ColorDepthTrueColor, "\x1b[0;48;2;1;2;3m"},
+		{"rgb to 256", RGB(255, 128, 0), ColorDepth256, "\x1b[0;38;5;208m"},
+		{"rgb gray to 256", RGB(100, 100, 100), ColorDepth256, "\x1b[0;38;5;241m"},
+		{"rgb to 16", RGB(250, 10, 10), ColorDepth16, "\x1b[0;91m"},
+	}
+	for _, tc := range cases {
+		if actual := tc.Color.ANSI(tc.Depth); actual != tc.Expected {
+			t.Errorf("%s: expected %q, got %q", tc.Name, tc.Expected, actual)
+		}
+	}
+}
+
+func Test_DetectColorDepth(t *testing.T) {
+	cases := []struct {
+		ColorTerm string
+		Term      string
+		Expected  ColorDepth
+	}{
+		{"", "", ColorDepth16},
+		{"", "xterm", ColorDepth16},
+		{"", "xterm-256color", ColorDepth256},
+		{"truecolor", "xterm-256color", ColorDepthTrueColor},
+		{"24bit", "", ColorDepthTrueColor},
+		{"", "xterm-direct", ColorDepthTrueColor},
+	}
+	defer setEnv("COLORTERM", "")()
+	defer setEnv("TERM", "")()
+	for _, tc := range cases {
+		os.Setenv("COLORTERM", tc.ColorTerm) //nolint:errcheck
+		os.Setenv("TERM", tc.Term)           //nolint:errcheck
+		if actual := DetectColorDepth(); actual != tc.Expected {
+			t.Errorf("COLORTERM=%q TERM=%q: expected %d, got %d", tc.ColorTerm, tc.Term, tc.Expected, actual)
+		}
+	}
+}
+
+func Test_StyledPaletteResets(t *testing.T) {
+	p := DefaultPalette
+	p[Info][0] = White.Bold()
+	colors := p.toANSIDepth(ColorDepth16)
+	if expected := "\x1b[0;1;97m"; colors[Info][0] != expected {
+		t.Errorf("expected %q, got %q", expected, colors[Info][0])
+	}
+	if expected := "\x1b[0;37m"; colors[Info][1] != expected {
+		t.Errorf("expected plain colors to reset styles, got %q", colors[Info][1])
+	}
+
+	if colors := DefaultPalette.toANSIDepth(ColorDepthTrueColor); colors[Info][0] != ansi.FgWhite {
+		t.Errorf("expected plain palette to be unchanged, got %q", colors[Info][0])
+	}
+}
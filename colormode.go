@@ -0,0 +1,81 @@
+package frog
+
+import (
+	"os"
+	"strings"
+)
+
+// ColorMode controls whether text-based Printers use ANSI colors.
+type ColorMode byte
+
+const (
+	// ColorAuto uses colors if the printer has a palette, unless the environment says otherwise
+	// (see ColorModeFromEnv).
+	ColorAuto ColorMode = iota
+	// ColorAlways uses colors, even when the output isn't a terminal (e.g. when piping to `less -R`).
+	// If set via POColor on a printer that has no palette, DefaultPalette is used.
+	ColorAlways
+	// ColorNever never uses colors.
+	ColorNever
+)
+
+func (m ColorMode) String() string {
+	switch m {
+	case ColorAuto:
+		return "auto"
+	case ColorAlways:
+		return "always"
+	case ColorNever:
+		return "never"
+	}
+	return "unknown"
+}
+
+// ColorModeFromEnv returns the color mode requested by the environment, checking (in order):
+//   - NO_COLOR: if set, colors are disabled (see https://no-color.org)
+//   - FORCE_COLOR: if set, colors are enabled, unless it is "0" or "false"
+//   - CLICOLOR_FORCE: if set to anything other than "" or "0", colors are enabled
+//   - CLICOLOR: if "0", colors are disabled
+//
+// If none of those apply, it returns ColorAuto.
+// Root loggers (e.g. NewBuffered, NewUnbuffered) check the environment when they are created, and
+// an explicit POColor option takes precedence over it. The environment only decides whether colors
+// are used; it never gives a palette to a printer that doesn't have one. When colors are forced and
+// stdout isn't a terminal, New(Auto) picks AutoUnbuffered (which has a palette) instead of Basic.
+func ColorModeFromEnv() ColorMode {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return ColorNever
+	}
+	if v, ok := os.LookupEnv("FORCE_COLOR"); ok {
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "0", "false":
+			return ColorNever
+		}
+		return ColorAlways
+	}
+	if v := os.Getenv("CLICOLOR_FORCE"); len(v) > 0 && v != "0" {
+		return ColorAlways
+	}
+	if os.Getenv("CLICOLOR") == "0" {
+		return ColorNever
+	}
+	return ColorAuto
+}
+
+// defaultANSIColors is used when colors are forced on a printer that has no palette
+var defaultANSIColors = DefaultPalette.toANSIDepth(ColorDepth16)
+
+// colorsForced returns true if colors are forced on by the passed in options (with the last POColor
+// winning), or by the environment if the options don't set a color mode.
+func colorsForced(opts []PrinterOption) bool {
+	mode := ColorAuto
+	for _, o := range opts {
+		if oc, ok := o.(poColor); ok {
+			mode = oc.Mode
+		}
+	}
+	if mode == ColorAuto {
+		mode = ColorModeFromEnv()
+	}
+	return mode == ColorAlways
+}
@@ -0,0 +1,107 @@
+package frog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_ColorModeFromEnv(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Env      map[string]string
+		Expected ColorMode
+	}{
+		{"none", nil, ColorAuto},
+		{"no color", map[string]string{"NO_COLOR": "1"}, ColorNever},
+		{"no color empty", map[string]string{"NO_COLOR": ""}, ColorNever},
+		{"no color beats force", map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"}, ColorNever},
+		{"force color", map[string]string{"FORCE_COLOR": "1"}, ColorAlways},
+		{"force color empty", map[string]string{"FORCE_COLOR": ""}, ColorAlways},
+		{"force color 0", map[string]string{"FORCE_COLOR": "0"}, ColorNever},
+		{"force color false", map[string]string{"FORCE_COLOR": "false"}, ColorNever},
+		{"clicolor force", map[string]string{"CLICOLOR_FORCE": "1"}, ColorAlways},
+		{"clicolor force 0", map[string]string{"CLICOLOR_FORCE": "0"}, ColorAuto},
+		{"clicolor force beats clicolor", map[string]string{"CLICOLOR_FORCE": "1", "CLICOLOR": "0"}, ColorAlways},
+		{"clicolor 0", map[string]string{"CLICOLOR": "0"}, ColorNever},
+		{"clicolor 1", map[string]string{"CLICOLOR": "1"}, ColorAuto},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			for k, v := range tc.Env {
+				defer setEnv(k, v)()
+			}
+			if actual := ColorModeFromEnv(); actual != tc.Expected {
+				t.Errorf("expected %v, got %v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func Test_ColorModePerRoot(t *testing.T) {
+	colored := func(log RootLogger, buf *bytes.Buffer) bool {
+		log.Info("hi")
+		log.Close()
+		return strings.Contains(buf.String(), "\x1b[")
+	}
+	palette := TextPrinter{palette: DefaultPalette.toANSI(), printLevel: true}
+	noPalette := TextPrinter{printLevel: true}
+
+	cases := []struct {
+		Name     string
+		Env      string
+		Printer  TextPrinter
+		Opts     []PrinterOption
+		Expected bool
+	}{
+		{"auto", "", palette, nil, true},
+		{"auto no palette", "", noPalette, nil, false},
+		{"env never", "NO_COLOR", palette, nil, false},
+		{"env always", "FORCE_COLOR", palette, nil, true},
+		{"env always no palette", "FORCE_COLOR", noPalette, nil, false},
+		{"env always no palette clicolor", "CLICOLOR_FORCE", noPalette, nil, false},
+		{"option never", "", palette, []PrinterOption{POColor(ColorNever)}, false},
+		{"option always", "", noPalette, []PrinterOption{POColor(ColorAlways)}, true},
+		{"option beats env never", "NO_COLOR", palette, []PrinterOption{POColor(ColorAlways)}, true},
+		{"option beats env always", "CLICOLOR_FORCE", palette, []PrinterOption{POColor(ColorNever)}, false},
+		{"option auto defers to env", "NO_COLOR", palette, []PrinterOption{POColor(ColorAuto)}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if len(tc.Env) > 0 {
+				defer setEnv(tc.Env, "1")()
+			}
+			var buf bytes.Buffer
+			prn := tc.Printer
+			log := NewUnbuffered(&buf, prn.SetOptions(tc.Opts...))
+			if actual := colored(log, &buf); actual != tc.Expected {
+				t.Errorf("expected colors=%v, got %q", tc.Expected, buf.String())
+			}
+		})
+	}
+}
+
+func Test_ColorModeEnvCheckedPerRoot(t *testing.T) {
+	var before, after bytes.Buffer
+	logBefore := NewUnbuffered(&before, &TextPrinter{palette: DefaultPalette.toANSI()})
+	defer setEnv("NO_COLOR", "1")()
+	logAfter := NewUnbuffered(&after, &TextPrinter{palette: DefaultPalette.toANSI()})
+
+	logBefore.Info("hi")
+	logAfter.Info("hi")
+	if !strings.Contains(before.String(), "\x1b[") {
+		t.Errorf("expected logger created before NO_COLOR was set to use colors, got %q", before.String())
+	}
+	if strings.Contains(after.String(), "\x1b[") {
+		t.Errorf("expected logger created after NO_COLOR was set to not use colors, got %q", after.String())
+	}
+}
+
+func Test_ColorModePerLine(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewUnbuffered(&buf, &TextPrinter{palette: DefaultPalette.toANSI()})
+	WithOptions(log, POColor(ColorNever)).Info("plain")
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("expected no colors, got %q", buf.String())
+	}
+}
@@ -0,0 +1,226 @@
+package frog
+
+import (
+	"context"
+	"sync"
+)
+
+// ctxKey is the context key for the Logger stored by NewContext
+type ctxKey struct{}
+
+// NewContext returns a copy of the passed in context that holds the passed in Logger (see
+// FromContext).
+func NewContext(ctx context.Context, log Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, log)
+}
+
+// FromContext returns the Logger stored in the passed in context by NewContext, or a NullLogger if
+// there isn't one (or if ctx is nil). It does not add any fields from context extractors (see
+// WithContext).
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if log, ok := ctx.Value(ctxKey{}).(Logger); ok && log != nil {
+			return log
+		}
+	}
+	return &NullLogger{}
+}
+
+// ContextExtractor pulls request-scoped values (e.g. a request id) out of a context, as fields.
+// Register them with RegisterContextExtractor.
+type ContextExtractor interface {
+	Extract(ctx context.Context) []Fielder
+}
+
+// ContextExtractorFunc adapts a func to a ContextExtractor.
+type ContextExtractorFunc func(ctx context.Context) []Fielder
+
+func (fn ContextExtractorFunc) Extract(ctx context.Context) []Fielder {
+	return fn(ctx)
+}
+
+// ContextValue returns a ContextExtractor that adds a field with the passed in name, if the context
+// has a non-nil value for the passed in key. The field's value is made with Any.
+func ContextValue(key interface{}, name string) ContextExtractor {
+	return ContextExtractorFunc(func(ctx context.Context) []Fielder {
+		v := ctx.Value(key)
+		if v == nil {
+			return nil
+		}
+		return []Fielder{Any(name, v)}
+	})
+}
+
+var (
+	extractorsMu sync.RWMutex
+	extractors   []*registeredExtractor
+)
+
+// registeredExtractor is a pointer, so that it can be found again when unregistering
+type registeredExtractor struct {
+	ContextExtractor
+}
+
+// RegisterContextExtractor adds an extractor that is used by WithContext and the *Ctx logging funcs
+// (e.g. InfoCtx) to add fields from a context. Extractors are called in the order they were
+// registered. Call the returned func to unregister the extractor.
+func RegisterContextExtractor(e ContextExtractor) (unregister func()) {
+	re := &registeredExtractor{e}
+	extractorsMu.Lock()
+	extractors = append(extractors, re)
+	extractorsMu.Unlock()
+
+	return func() {
+		extractorsMu.Lock()
+		defer extractorsMu.Unlock()
+		for i, other := range extractors {
+			if other == re {
+				// copy, so that any concurrent readers of the old slice are unaffected
+				extractors = append(extractors[:i:i], extractors[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// ContextFields returns the fields from every registered extractor for the passed in context.
+func ContextFields(ctx context.Context) []Fielder {
+	if ctx == nil {
+		return nil
+	}
+	extractorsMu.RLock()
+	list := extractors
+	extractorsMu.RUnlock()
+
+	var out []Fielder
+	for _, e := range list {
+		out = append(out, e.Extract(ctx)...)
+	}
+	return out
+}
+
+// WithContext creates a new Logger that wraps the passed Logger, adding the fields from every
+// registered extractor for the passed in context (see RegisterContextExtractor). If there are no
+// such fields, the passed Logger is returned as-is.
+func WithContext(log Logger, ctx context.Context) Logger {
+	fielders := ContextFields(ctx)
+	if len(fielders) == 0 {
+		return log
+	}
+	return WithFields(log, fielders...)
+}
+
+// The *Ctx funcs log to the Logger stored in the passed in context (see FromContext), adding the
+// fields from every registered extractor (see WithContext). If the context has no Logger (or holds
+// a NullLogger, which would discard the line anyway), or if the line would be filtered out by a min
+// level, they return without running any extractors (except for FatalCtx, which still exits).
+
+// ctxLogger returns the context's Logger with the fields from every registered extractor, or false
+// if there's no need to log a line at the passed in level (in which case the returned Logger has
+// no extra fields, and is a NullLogger if the context has no Logger).
+func ctxLogger(ctx context.Context, level Level) (Logger, bool) {
+	log := FromContext(ctx)
+	if _, ok := log.(*NullLogger); ok {
+		return log, false
+	}
+	if !wouldLog(log, level) {
+		return log, false
+	}
+	return WithContext(log, ctx), true
+}
+
+// wouldLog returns false if a line at the passed in level would be filtered out by the min levels
+// of the passed in Logger and its parents (including any min levels set for named Loggers). When
+// unsure (e.g. Transient lines on anchored Loggers), it returns true.
+func wouldLog(log Logger, level Level) bool {
+	return wouldLogFrom(log, level, Transient, "")
+}
+
+func wouldLogFrom(log Logger, level Level, min Level, name string) bool {
+	for log != nil {
+		minLevel := log.MinLevel()
+		switch l := log.(type) {
+		case *AnchoredLogger:
+			if level == Transient {
+				return true // anchored Transient lines are drawn regardless of min level
+			}
+		case *NamedLogger:
+			if len(name) == 0 {
+				name = l.Name()
+			}
+		case *TeeLogger:
+			if wouldLogFrom(l.Secondary, level, maxLevel(min, minLevel), name) {
+				return true
+			}
+		case LevelRegistrar:
+			// this is how a root Logger applies its min level (see Buffered.LogImpl)
+			minLevel = l.LevelRegistry().minLevel(name, minLevel)
+		}
+		min = maxLevel(min, minLevel)
+		log = Parent(log)
+	}
+	return level >= min
+}
+
+func maxLevel(a, b Level) Level {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// LogCtx logs a string (with optional fielders) at the passed in level to the context's Logger.
+func LogCtx(ctx context.Context, level Level, msg string, fielders ...Fielder) {
+	if log, ok := ctxLogger(ctx, level); ok || level == Fatal {
+		log.Log(level, msg, fielders...)
+	}
+}
+
+// TransientCtx logs a Transient line to the context's Logger (see LogCtx).
+func TransientCtx(ctx context.Context, msg string, fielders ...Fielder) {
+	if log, ok := ctxLogger(ctx, Transient); ok {
+		log.Transient(msg, fielders...)
+	}
+}
+
+// TraceCtx logs a Trace line to the context's Logger (see LogCtx).
+func TraceCtx(ctx context.Context, msg string, fielders ...Fielder) {
+	if log, ok := ctxLogger(ctx, Trace); ok {
+		log.Trace(msg, fielders...)
+	}
+}
+
+// VerboseCtx logs a Verbose line to the context's Logger (see LogCtx).
+func VerboseCtx(ctx context.Context, msg string, fielders ...Fielder) {
+	if log, ok := ctxLogger(ctx, Verbose); ok {
+		log.Verbose(msg, fielders...)
+	}
+}
+
+// InfoCtx logs an Info line to the context's Logger (see LogCtx).
+func InfoCtx(ctx context.Context, msg string, fielders ...Fielder) {
+	if log, ok := ctxLogger(ctx, Info); ok {
+		log.Info(msg, fielders...)
+	}
+}
+
+// WarningCtx logs a Warning line to the context's Logger (see LogCtx).
+func WarningCtx(ctx context.Context, msg string, fielders ...Fielder) {
+	if log, ok := ctxLogger(ctx, Warning); ok {
+		log.Warning(msg, fielders...)
+	}
+}
+
+// ErrorCtx logs an Error line to the context's Logger (see LogCtx).
+func ErrorCtx(ctx context.Context, msg string, fielders ...Fielder) {
+	if log, ok := ctxLogger(ctx, Error); ok {
+		log.Error(msg, fielders...)
+	}
+}
+
+// FatalCtx logs a Fatal line to the context's Logger (see LogCtx), then closes its root Logger and
+// exits (see Logger.Fatal).
+func FatalCtx(ctx context.Context, msg string, fielders ...Fielder) {
+	log, _ := ctxLogger(ctx, Fatal)
+	log.Fatal(msg, fielders...)
+}
@@ -0,0 +1,166 @@
+package frog
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+)
+
+type testCtxKey string
+
+func Test_FromContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()).(*NullLogger); !ok {
+		t.Errorf("expected a NullLogger from a context without a Logger")
+	}
+	if _, ok := FromContext(nil).(*NullLogger); !ok {
+		t.Errorf("expected a NullLogger from a nil context")
+	}
+
+	log := NewUnbuffered(&bytes.Buffer{}, &TextPrinter{})
+	ctx := NewContext(context.Background(), log)
+	if FromContext(ctx) != log {
+		t.Errorf("expected the Logger stored in the context")
+	}
+
+	// NullLogger fallback is safe to use
+	InfoCtx(context.Background(), "nobody is listening")
+}
+
+func Test_ContextExtractors(t *testing.T) {
+	defer RegisterContextExtractor(ContextValue(testCtxKey("request"), "request_id"))()
+	defer RegisterContextExtractor(ContextExtractorFunc(func(ctx context.Context) []Fielder {
+		if user, ok := ctx.Value(testCtxKey("user")).(int); ok {
+			return []Fielder{Int("user_id", user)}
+		}
+		return nil
+	}))()
+
+	var buf bytes.Buffer
+	log := NewUnbuffered(&buf, &TextPrinter{printLevel: true})
+	log.SetMinLevel(Trace)
+	ctx := NewContext(context.Background(), WithFields(log, String("svc", "api")))
+
+	InfoCtx(ctx, "no values")
+	ctx = context.WithValue(ctx, testCtxKey("request"), "r-123")
+	WarningCtx(ctx, "request", Int("n", 1))
+	ctx = context.WithValue(ctx, testCtxKey("user"), 42)
+	TraceCtx(ctx, "trace")
+	VerboseCtx(ctx, "verbose")
+	ErrorCtx(ctx, "error")
+	LogCtx(ctx, Info, "log")
+	WithContext(log, ctx).Info("with context")
+
+	expected := "" +
+		"[nfo] no values      svc=api\n" +
+		"[WRN] request   svc=api request_id=r-123 n=1\n" +
+		"[trc] trace     svc=api request_id=r-123 user_id=42\n" +
+		"[dbg] verbose   svc=api request_id=r-123 user_id=42\n" +
+		"[ERR] error     svc=api request_id=r-123 user_id=42\n" +
+		"[nfo] log       svc=api request_id=r-123 user_id=42\n" +
+		"[nfo] with context   request_id=r-123 user_id=42\n"
+	if buf.String() != expected {
+		t.Errorf("\nexpected:\n%s\nactual:\n%s", expected, buf.String())
+	}
+}
+
+func Test_UnregisterContextExtractor(t *testing.T) {
+	ctx := context.WithValue(context.Background(), testCtxKey("request"), "r-123")
+
+	unregisterA := RegisterContextExtractor(ContextValue(testCtxKey("request"), "a"))
+	unregisterB := RegisterContextExtractor(ContextValue(testCtxKey("request"), "b"))
+	if n := len(ContextFields(ctx)); n != 2 {
+		t.Errorf("expected 2 fields, got %d", n)
+	}
+
+	unregisterA()
+	unregisterA() // safe to call more than once
+	fields := ContextFields(ctx)
+	if len(fields) != 1 || fields[0].Field().Name != "b" {
+		t.Errorf("expected just the b field, got %v", fields)
+	}
+
+	unregisterB()
+	log := &NullLogger{}
+	if WithContext(log, ctx) != Logger(log) {
+		t.Errorf("expected WithContext to return the passed Logger when there are no fields to add")
+	}
+}
+
+func Test_CtxFuncsWithoutLogger(t *testing.T) {
+	calls := 0
+	defer RegisterContextExtractor(ContextExtractorFunc(func(ctx context.Context) []Fielder {
+		calls++
+		return []Fielder{String("extracted", "yes")}
+	}))()
+
+	// without a Logger in the context, there is nothing to log to, so no extractors should run
+	ctx := context.Background()
+	TransientCtx(ctx, "")
+	TraceCtx(ctx, "")
+	VerboseCtx(ctx, "")
+	InfoCtx(ctx, "")
+	WarningCtx(ctx, "")
+	ErrorCtx(ctx, "")
+	LogCtx(ctx, Info, "")
+	InfoCtx(nil, "")
+	if calls != 0 {
+		t.Errorf("expected no extractors to run, but they ran %d times", calls)
+	}
+
+	var exitCodes []int
+	origExit := exit
+	exit = func(code int) { exitCodes = append(exitCodes, code) }
+	defer func() { exit = origExit }()
+	FatalCtx(ctx, "")
+	LogCtx(ctx, Fatal, "")
+	if len(exitCodes) != 2 {
+		t.Errorf("expected FatalCtx and LogCtx(Fatal) to exit even without a Logger, got %v", exitCodes)
+	}
+
+	InfoCtx(NewContext(ctx, NewUnbuffered(ioutil.Discard, &TextPrinter{})), "")
+	if calls != 1 {
+		t.Errorf("expected extractors to run once there is a Logger, but they ran %d times", calls)
+	}
+}
+
+func Test_CtxFuncsBelowMinLevel(t *testing.T) {
+	calls := 0
+	defer RegisterContextExtractor(ContextExtractorFunc(func(ctx context.Context) []Fielder {
+		calls++
+		return []Fielder{String("extracted", "yes")}
+	}))()
+
+	newRoot := func() *Unbuffered {
+		root := NewUnbuffered(ioutil.Discard, &TextPrinter{})
+		root.SetMinLevel(Warning)
+		root.LevelRegistry().SetLevel("db.*", Verbose)
+		return root
+	}
+	named := Named(WithFields(newRoot(), Int("n", 1)), "db")
+
+	cases := []struct {
+		Name     string
+		Log      Logger
+		Level    Level
+		Expected bool // whether the extractors should run
+	}{
+		{"below root", newRoot(), Info, false},
+		{"at root", newRoot(), Warning, true},
+		{"below child", WithFields(newRoot(), Int("n", 1)).SetMinLevel(Error), Warning, false},
+		{"below named", named, Trace, false},
+		{"at named", named, Verbose, true},
+		{"named child", Named(named, "pool"), Verbose, true},
+		{"other name", Named(newRoot(), "http"), Verbose, false},
+		{"anchored transient", AddAnchor(NewBuffered(ioutil.Discard, false, &TextPrinter{})), Transient, true},
+		{"tee below both", &TeeLogger{Primary: newRoot(), Secondary: newRoot()}, Info, false},
+		{"tee secondary", &TeeLogger{Primary: newRoot(), Secondary: NewUnbuffered(ioutil.Discard, &TextPrinter{})}, Info, true},
+	}
+	for _, tc := range cases {
+		calls = 0
+		LogCtx(NewContext(context.Background(), tc.Log), tc.Level, "")
+		if actual := calls > 0; actual != tc.Expected {
+			t.Errorf("%s: expected extractors to run: %v, got %v", tc.Name, tc.Expected, actual)
+		}
+	}
+}
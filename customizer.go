@@ -5,14 +5,14 @@ type CustomizerLogger struct {
 	parent   Logger
 	opts     []PrinterOption
 	fields   []Field
-	minLevel Level // defaults to Transient
+	minLevel atomicLevel // defaults to Transient
 }
 
 func newCustomizerLogger(l Logger, opts []PrinterOption, fielders []Fielder) *CustomizerLogger {
 	return &CustomizerLogger{
 		parent: l,
 		opts:   opts,
-		fields: Fieldify(fielders),
+		fields: fieldifyDeferred(fielders),
 	}
 }
 
@@ -21,16 +21,16 @@ func (l *CustomizerLogger) Parent() Logger {
 }
 
 func (l *CustomizerLogger) MinLevel() Level {
-	return l.minLevel
+	return l.minLevel.Load()
 }
 
 func (l *CustomizerLogger) SetMinLevel(level Level) Logger {
-	l.minLevel = level
+	l.minLevel.Store(level)
 	return l
 }
 
 func (l *CustomizerLogger) LogImpl(level Level, msg string, fielders []Fielder, opts []PrinterOption, d ImplData) {
-	d.MergeMinLevel(l.minLevel)
+	d.MergeMinLevel(l.minLevel.Load())
 	d.MergeFields(l.fields)
 	l.parent.LogImpl(level, msg, fielders, append(l.opts, opts...), d)
 }
@@ -40,6 +40,11 @@ func (l *CustomizerLogger) Transient(msg string, fielders ...Fielder) Logger {
 	return l
 }
 
+func (l *CustomizerLogger) Trace(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Trace, msg, fielders, nil, ImplData{})
+	return l
+}
+
 func (l *CustomizerLogger) Verbose(msg string, fielders ...Fielder) Logger {
 	l.LogImpl(Verbose, msg, fielders, nil, ImplData{})
 	return l
@@ -60,7 +65,16 @@ func (l *CustomizerLogger) Error(msg string, fielders ...Fielder) Logger {
 	return l
 }
 
+func (l *CustomizerLogger) Fatal(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Fatal, msg, fielders, nil, ImplData{})
+	fatalExit(l)
+	return l
+}
+
 func (l *CustomizerLogger) Log(level Level, msg string, fielders ...Fielder) Logger {
 	l.LogImpl(level, msg, fielders, nil, ImplData{})
+	if level == Fatal {
+		fatalExit(l)
+	}
 	return l
 }
@@ -1,8 +1,80 @@
 package frog
 
-import "testing"
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
 
 func Test_CustomizerLoggerInterfaces(t *testing.T) {
 	var _ Logger = &CustomizerLogger{}
 	var _ ChildLogger = &CustomizerLogger{}
 }
+
+func Test_LazyFields(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewUnbuffered(&buf, &TextPrinter{})
+	log.SetMinLevel(Info)
+
+	calls := 0
+	expensive := func() Fielder {
+		calls++
+		return Int("ignored", calls)
+	}
+
+	child := WithFields(log, Lazy("n", expensive))
+	if calls != 0 {
+		t.Fatalf("expected WithFields to defer evaluation, but fn was called %d times", calls)
+	}
+
+	child.Verbose("filtered")
+	log.Verbose("filtered", Lazy("m", expensive))
+	if calls != 0 {
+		t.Fatalf("expected filtered lines to skip evaluation, but fn was called %d times", calls)
+	}
+
+	child.Info("one")
+	child.Info("two", Lazy("m", expensive))
+	log.Close()
+
+	if calls != 3 {
+		t.Errorf("expected fn to be called 3 times, got %d", calls)
+	}
+	expected := "one       n=1\ntwo       n=2 m=3"
+	if actual := strings.TrimSpace(buf.String()); actual != expected {
+		t.Errorf("expected:\n%s\nactual:\n%s", expected, actual)
+	}
+
+	if f := Lazy("nil", nil).Field(); f.Value != "null" {
+		t.Errorf("expected nil fn to produce null, got %#v", f)
+	}
+}
+
+func Test_LazyFieldPointer(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewUnbuffered(&buf, &TextPrinter{})
+	log.SetMinLevel(Info)
+
+	calls := 0
+	lazy := Lazy("n", func() Fielder {
+		calls++
+		return Int("ignored", calls)
+	})
+
+	// a *FieldLazy should be deferred just like a FieldLazy
+	child := WithFields(log, &lazy)
+	if calls != 0 {
+		t.Fatalf("expected WithFields to defer evaluation, but fn was called %d times", calls)
+	}
+	child.Verbose("filtered")
+	if calls != 0 {
+		t.Fatalf("expected filtered lines to skip evaluation, but fn was called %d times", calls)
+	}
+	child.Info("one")
+	child.Info("two")
+
+	expected := "one       n=1\ntwo       n=2"
+	if actual := strings.TrimSpace(buf.String()); actual != expected {
+		t.Errorf("expected:\n%s\nactual:\n%s", expected, actual)
+	}
+}
@@ -0,0 +1,163 @@
+package frog
+
+import "strconv"
+
+// DuplicatePolicy controls what a Printer does when more than one field on a line has the same
+// name (e.g. when a field passed with the line has the same name as a field added by WithFields).
+type DuplicatePolicy byte
+
+const (
+	// DuplicatesKeepAll prints every field, even if their names collide (the default).
+	DuplicatesKeepAll DuplicatePolicy = iota
+	// DuplicatesLastWins keeps only the last field with a given name, in the position of the first.
+	// Since fields from parent Loggers come first, this lets a child (or the line itself) override
+	// a parent's field.
+	DuplicatesLastWins
+	// DuplicatesFirstWins keeps only the first field with a given name, so parent fields can't be
+	// overridden.
+	DuplicatesFirstWins
+	// DuplicatesSuffix keeps every field, but renames later duplicates by adding a numbered suffix
+	// (e.g. "name", "name_2", "name_3").
+	DuplicatesSuffix
+)
+
+func (p DuplicatePolicy) String() string {
+	switch p {
+	case DuplicatesKeepAll:
+		return "KeepAll"
+	case DuplicatesLastWins:
+		return "LastWins"
+	case DuplicatesFirstWins:
+		return "FirstWins"
+	case DuplicatesSuffix:
+		return "Suffix"
+	}
+	return "DuplicatePolicy(" + strconv.Itoa(int(p)) + ")"
+}
+
+// resolveDuplicates applies the policy to the passed in fields. If there are no duplicates, the
+// passed in slice is returned unmodified, otherwise a new slice is returned.
+// The name of a named Logger is not a user field, so it is left alone, and never counts as a
+// duplicate of a user field that happens to be named "logger".
+func resolveDuplicates(fields []Field, policy DuplicatePolicy) []Field {
+	if policy == DuplicatesKeepAll || !hasDuplicateNames(fields) {
+		return fields
+	}
+
+	out := make([]Field, 0, len(fields))
+	index := make(map[string]int, len(fields)) // name -> index in out
+
+	switch policy {
+	case DuplicatesLastWins, DuplicatesFirstWins:
+		for _, f := range fields {
+			if f.isLoggerName {
+				out = append(out, f)
+				continue
+			}
+			i, ok := index[f.Name]
+			if !ok {
+				index[f.Name] = len(out)
+				out = append(out, f)
+			} else if policy == DuplicatesLastWins {
+				out[i] = f
+			}
+		}
+	case DuplicatesSuffix:
+		// reserve all the original names first, so a suffixed name can't collide with a later field
+		for i, f := range fields {
+			if _, ok := index[f.Name]; !ok && !f.isLoggerName {
+				index[f.Name] = i
+			}
+		}
+		seen := make(map[string]int, len(fields)) // name -> times seen
+		for i, f := range fields {
+			if f.isLoggerName {
+				out = append(out, f)
+				continue
+			}
+			seen[f.Name]++
+			if index[f.Name] != i {
+				base := f.Name
+				for n := seen[base]; ; n++ {
+					f.Name = base + "_" + strconv.Itoa(n)
+					if _, taken := index[f.Name]; !taken {
+						break
+					}
+				}
+				index[f.Name] = i
+			}
+			out = append(out, f)
+		}
+	default:
+		return fields
+	}
+	return out
+}
+
+// renameLoggerFieldCollisions renames any user fields that have the same name as the field that
+// holds the name of a named Logger, by adding a numbered suffix (as DuplicatesSuffix would).
+// JSONPrinter uses this so that a user's "logger" field can't collide with the Logger's name.
+func renameLoggerFieldCollisions(fields []Field) []Field {
+	var out []Field
+	for i, f := range fields {
+		if !f.isLoggerName {
+			continue
+		}
+		for j, other := range fields {
+			if j == i || other.isLoggerName || other.Name != f.Name {
+				continue
+			}
+			if out == nil {
+				out = append(make([]Field, 0, len(fields)), fields...)
+			}
+			for n := 2; ; n++ {
+				name := f.Name + "_" + strconv.Itoa(n)
+				if !hasFieldNamed(out, name) {
+					out[j].Name = name
+					break
+				}
+			}
+		}
+		break
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// withoutLoggerName returns the fields with the name of a named Logger marked as a regular field,
+// copying the slice first if needed.
+func withoutLoggerName(fields []Field) []Field {
+	for i, f := range fields {
+		if f.isLoggerName {
+			out := append(make([]Field, 0, len(fields)), fields...)
+			out[i].isLoggerName = false
+			return out
+		}
+	}
+	return fields
+}
+
+func hasFieldNamed(fields []Field, name string) bool {
+	for _, f := range fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDuplicateNames(fields []Field) bool {
+	for i := 1; i < len(fields); i++ {
+		if fields[i].isLoggerName {
+			continue
+		}
+		for j := 0; j < i; j++ {
+			if fields[i].Name == fields[j].Name && !fields[j].isLoggerName {
+				return true
+			}
+		}
+	}
+	return false
+}
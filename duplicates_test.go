@@ -0,0 +1,50 @@
+package frog
+
+import (
+	"testing"
+)
+
+func Test_DuplicateFields(t *testing.T) {
+	cases := []struct {
+		Policy       DuplicatePolicy
+		ExpectedText string
+		ExpectedJSON string
+	}{
+		{
+			DuplicatesKeepAll,
+			`a=1 b=2 a=3 a_2=4 a=5`,
+			`"a":1,"b":2,"a":3,"a_2":4,"a":5`,
+		},
+		{
+			DuplicatesLastWins,
+			`a=5 b=2 a_2=4`,
+			`"a":5,"b":2,"a_2":4`,
+		},
+		{
+			DuplicatesFirstWins,
+			`a=1 b=2 a_2=4`,
+			`"a":1,"b":2,"a_2":4`,
+		},
+		{
+			DuplicatesSuffix,
+			`a=1 b=2 a_3=3 a_2=4 a_4=5`,
+			`"a":1,"b":2,"a_3":3,"a_2":4,"a_4":5`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Policy.String(), func(t *testing.T) {
+			opts := []PrinterOption{PODuplicateFields(tc.Policy)}
+			testOutput(t, opts, func(log Logger) {
+				WithFields(log, Int("a", 1), Int("b", 2)).Info("", Int("a", 3), Int("a_2", 4), Int("a", 5))
+			}, tc.ExpectedText, tc.ExpectedJSON)
+		})
+	}
+}
+
+func Test_DuplicateFieldsNoAlloc(t *testing.T) {
+	fields := []Field{{Name: "a"}, {Name: "b"}}
+	if out := resolveDuplicates(fields, DuplicatesSuffix); &out[0] != &fields[0] {
+		t.Errorf("expected fields without duplicates to be returned as-is")
+	}
+}
@@ -0,0 +1,188 @@
+package frog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorFielder can be implemented by errors that have structured data of their own (e.g. a
+// status code or the name of a file), so that ErrChain can include that data in the log line.
+type ErrorFielder interface {
+	FrogFields() []Fielder
+}
+
+// ErrChain adds a field named "error" that includes the error's message, and then walks the
+// error's chain of wrapped errors (via Unwrap() error and Unwrap() []error) to record the Go type
+// of each cause, along with any fields from causes that implement ErrorFielder.
+// In JSON, this is a nested object, e.g.:
+//
+//	{"msg":"load: open x: not found","type":"*fmt.wrapError","causes":[{"msg":"open x: not found","type":"*fs.PathError"...}]}
+//
+// In text, this is the message followed by a list of the causes' types, e.g.:
+//
+//	"load: open x: not found" [*fmt.wrapError > *fs.PathError > syscall.Errno]
+func ErrChain(value error) FieldErrorChain {
+	return FieldErrorChain{Name: "error", Value: value}
+}
+
+// ErrChainN adds an error chain field with a custom name. It is a short alias for ErrChainNamed.
+func ErrChainN(name string, value error) FieldErrorChain {
+	return ErrChainNamed(name, value)
+}
+
+// ErrChainNamed adds an error chain field (see ErrChain) with a custom name.
+func ErrChainNamed(name string, value error) FieldErrorChain {
+	return FieldErrorChain{Name: name, Value: value}
+}
+
+type FieldErrorChain struct {
+	Name  string
+	Value error
+}
+
+// errChainMaxCauses limits how many causes are recorded, in case of unusually large (or cyclic)
+// chains of errors.
+const errChainMaxCauses = 64
+
+func (f FieldErrorChain) Field() Field {
+	if f.Value == nil {
+		return Field{Name: f.Name, Value: "null"}
+	}
+
+	var js, txt strings.Builder
+	budget := errChainMaxCauses
+
+	js.WriteByte('{')
+	writeErrorJSON(&js, f.Value, &budget)
+	js.WriteByte('}')
+
+	txt.WriteString(QuoteText(errorString(f.Value)))
+	txt.WriteString(" [")
+	budget = errChainMaxCauses
+	writeErrorText(&txt, f.Value, &budget)
+	txt.WriteByte(']')
+
+	return Field{Name: f.Name, Value: js.String(), TextValue: txt.String(), Kind: KindError}
+}
+
+// unwrapErrors returns the errors wrapped by err (if any)
+func unwrapErrors(err error) (errs []error) {
+	// like Error, Unwrap could panic (e.g. if called on a nil pointer)
+	defer func() {
+		if r := recover(); r != nil {
+			errs = nil
+		}
+	}()
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	if inner := errors.Unwrap(err); inner != nil {
+		return []error{inner}
+	}
+	return nil
+}
+
+// writeErrorJSON writes the contents of the JSON object (minus the outer braces) that represents
+// err and its causes.
+func writeErrorJSON(sb *strings.Builder, err error, budget *int) {
+	*budget--
+
+	sb.WriteString(`"msg":"`)
+	sb.WriteString(escapeStringForJSON(errorString(err)))
+	sb.WriteString(`","type":"`)
+	sb.WriteString(escapeStringForJSON(fmt.Sprintf("%T", err)))
+	sb.WriteByte('"')
+
+	if ef, ok := err.(ErrorFielder); ok {
+		if fields := Fieldify(ef.FrogFields()); len(fields) > 0 {
+			sb.WriteString(`,"fields":{`)
+			for i, field := range fields {
+				if i > 0 {
+					sb.WriteByte(',')
+				}
+				writeJSONField(sb, field)
+			}
+			sb.WriteByte('}')
+		}
+	}
+
+	causes := unwrapErrors(err)
+	if len(causes) == 0 {
+		return
+	}
+	if *budget <= 0 {
+		sb.WriteString(`,"causes_truncated":true`)
+		return
+	}
+	sb.WriteString(`,"causes":[`)
+	n := 0
+	for _, cause := range causes {
+		if cause == nil || *budget <= 0 {
+			continue
+		}
+		if n > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteByte('{')
+		writeErrorJSON(sb, cause, budget)
+		sb.WriteByte('}')
+		n++
+	}
+	sb.WriteByte(']')
+}
+
+// writeErrorText writes err's type (and fields, if any), followed by its causes, where a single
+// cause is separated by " > ", and multiple causes (i.e. joined errors) are grouped in
+// parenthesis and separated by " | ".
+func writeErrorText(sb *strings.Builder, err error, budget *int) {
+	*budget--
+
+	fmt.Fprintf(sb, "%T", err)
+
+	if ef, ok := err.(ErrorFielder); ok {
+		if fields := Fieldify(ef.FrogFields()); len(fields) > 0 {
+			sb.WriteByte('{')
+			for i, field := range fields {
+				if i > 0 {
+					sb.WriteByte(' ')
+				}
+				sb.WriteString(field.Name)
+				sb.WriteByte('=')
+				sb.WriteString(textFieldValue(field))
+			}
+			sb.WriteByte('}')
+		}
+	}
+
+	var causes []error
+	for _, cause := range unwrapErrors(err) {
+		if cause != nil {
+			causes = append(causes, cause)
+		}
+	}
+	if len(causes) == 0 {
+		return
+	}
+	sb.WriteString(" > ")
+	if *budget <= 0 {
+		sb.WriteString("...")
+		return
+	}
+	if len(causes) == 1 {
+		writeErrorText(sb, causes[0], budget)
+		return
+	}
+	sb.WriteByte('(')
+	for i, cause := range causes {
+		if *budget <= 0 {
+			sb.WriteString(" | ...")
+			break
+		}
+		if i > 0 {
+			sb.WriteString(" | ")
+		}
+		writeErrorText(sb, cause, budget)
+	}
+	sb.WriteByte(')')
+}
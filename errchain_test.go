@@ -0,0 +1,109 @@
+package frog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type testStatusError struct {
+	Code int
+}
+
+func (e testStatusError) Error() string {
+	return fmt.Sprintf("status %d", e.Code)
+}
+
+func (e testStatusError) FrogFields() []Fielder {
+	return []Fielder{Int("code", e.Code), String("text", "not found")}
+}
+
+// testJoinError mimics the errors returned from errors.Join
+type testJoinError struct {
+	errs []error
+}
+
+func (e *testJoinError) Error() string {
+	var msgs []string
+	for _, err := range e.errs {
+		if err != nil {
+			msgs = append(msgs, err.Error())
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *testJoinError) Unwrap() []error {
+	return e.errs
+}
+
+// testCycleError wraps itself
+type testCycleError struct{}
+
+func (e *testCycleError) Error() string { return "cycle" }
+func (e *testCycleError) Unwrap() error { return e }
+
+func Test_ErrChain(t *testing.T) {
+	base := errors.New("base")
+	status := fmt.Errorf("request failed: %w", testStatusError{Code: 404})
+
+	cases := []struct {
+		Name         string
+		Err          error
+		ExpectedText string
+		ExpectedJSON string
+	}{
+		{
+			"nil",
+			nil,
+			`err=null`,
+			`"err":null`,
+		},
+		{
+			"single",
+			base,
+			`err=base [*errors.errorString]`,
+			`"err":{"msg":"base","type":"*errors.errorString"}`,
+		},
+		{
+			"wrapped",
+			fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", base)),
+			`err="outer: inner: base" [*fmt.wrapError > *fmt.wrapError > *errors.errorString]`,
+			`"err":{"msg":"outer: inner: base","type":"*fmt.wrapError","causes":[{"msg":"inner: base","type":"*fmt.wrapError","causes":[{"msg":"base","type":"*errors.errorString"}]}]}`,
+		},
+		{
+			"fields",
+			status,
+			`err="request failed: status 404" [*fmt.wrapError > frog.testStatusError{code=404 text="not found"}]`,
+			`"err":{"msg":"request failed: status 404","type":"*fmt.wrapError","causes":[{"msg":"status 404","type":"frog.testStatusError","fields":{"code":404,"text":"not found"}}]}`,
+		},
+		{
+			"joined",
+			&testJoinError{errs: []error{base, nil, status}},
+			`err="base; request failed: status 404" [*frog.testJoinError > (*errors.errorString | *fmt.wrapError > frog.testStatusError{code=404 text="not found"})]`,
+			`"err":{"msg":"base; request failed: status 404","type":"*frog.testJoinError","causes":[{"msg":"base","type":"*errors.errorString"},{"msg":"request failed: status 404","type":"*fmt.wrapError","causes":[{"msg":"status 404","type":"frog.testStatusError","fields":{"code":404,"text":"not found"}}]}]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			testFieldOutput(t, ErrChainN("err", tc.Err), tc.ExpectedText, tc.ExpectedJSON)
+		})
+	}
+}
+
+func Test_ErrChainCycle(t *testing.T) {
+	f := ErrChain(&testCycleError{}).Field()
+	if f.Name != "error" {
+		t.Errorf("expected default name to be \"error\", got %q", f.Name)
+	}
+	if !strings.HasSuffix(f.TextValue, " > ...]") {
+		t.Errorf("expected text to be truncated, got %s", f.TextValue)
+	}
+	var target map[string]interface{}
+	if err := json.Unmarshal([]byte(f.Value), &target); err != nil {
+		t.Errorf("expected valid JSON, got error %v:\n%s", err, f.Value)
+	}
+}
@@ -1,7 +1,9 @@
 package frog
 
 import (
+	"fmt"
 	"path/filepath"
+	"reflect"
 	"strconv"
 	"time"
 )
@@ -11,6 +13,57 @@ type Field struct {
 	Value        string
 	IsJSONString bool // if true, the string in Value should be bookended by double quotes to be valid JSON
 	IsJSONSafe   bool // if true, this string only contains alpha-numerics, spaces, and safe punctuation
+
+	// TextValue, if not empty, is displayed by text-based Printers instead of Value. This is for
+	// values whose JSON representation (e.g. a nested object) is not very readable.
+	// TextValue is displayed as-is, so any escaping or quoting must already be done (see QuoteText).
+	TextValue string
+
+	// Kind describes the type of the value, so that Printers can treat kinds differently (e.g. by
+	// coloring them). If left as KindUnknown, Printers infer the kind from the value (see Kind).
+	Kind FieldKind
+
+	// lazy is set for placeholder Fields whose value will be computed when the line is logged
+	lazy *FieldLazy
+
+	// isLoggerName is set for the LoggerField added for a named Logger (see ImplData.LoggerName), so
+	// that Printers can tell it apart from any other field that happens to be named "logger"
+	isLoggerName bool
+}
+
+// FieldKind describes the type of a Field's value.
+type FieldKind byte
+
+const (
+	KindUnknown FieldKind = iota
+	KindNumber
+	KindString
+	KindBool
+	KindDuration
+	KindError
+	KindNull
+	kindMax
+)
+
+// kind returns the field's Kind, or if that is KindUnknown, infers the kind from the value.
+// Values that are not strings, bools, nulls, or numbers (e.g. JSON objects) remain KindUnknown.
+func (f Field) kind() FieldKind {
+	if f.Kind != KindUnknown {
+		return f.Kind
+	}
+	if f.IsJSONString {
+		return KindString
+	}
+	switch f.Value {
+	case "null":
+		return KindNull
+	case "true", "false":
+		return KindBool
+	}
+	if len(f.Value) > 0 && (f.Value[0] == '-' || (f.Value[0] >= '0' && f.Value[0] <= '9')) {
+		return KindNumber
+	}
+	return KindUnknown
 }
 
 // Fielder is an interface used to add structured logging to calls to Logger methods
@@ -27,13 +80,41 @@ func Fieldify(f []Fielder) []Field {
 	return fields
 }
 
+// fieldifyDeferred is like Fieldify, except that any Lazy fielders are left as placeholders, to
+// be evaluated by FieldifyAndAppend once it is known that the line will be logged.
+func fieldifyDeferred(f []Fielder) []Field {
+	fields := make([]Field, len(f))
+	for i := range f {
+		switch lazy := f[i].(type) {
+		case FieldLazy:
+			fields[i] = Field{Name: lazy.Name, lazy: &lazy}
+			continue
+		case *FieldLazy:
+			if lazy != nil {
+				cp := *lazy // copy, so later changes to the caller's FieldLazy aren't seen
+				fields[i] = Field{Name: cp.Name, lazy: &cp}
+				continue
+			}
+		}
+		fields[i] = f[i].Field()
+	}
+	return fields
+}
+
 // FieldifyAndAppend returns a slice of Fields that starts with a copy the passed in []Field,
 // and then appends the passed in []Fielder, after first rendering them to Fields.
+// Any placeholders for Lazy fields are evaluated as they are copied.
 func FieldifyAndAppend(fields []Field, fielders []Fielder) []Field {
 	var out []Field
 	if len(fielders)+len(fields) > 0 {
 		out = make([]Field, len(fields), len(fielders)+len(fields))
-		copy(out, fields)
+		for i, field := range fields {
+			if field.lazy != nil {
+				out[i] = field.lazy.Field()
+			} else {
+				out[i] = field
+			}
+		}
 		for _, fielder := range fielders {
 			out = append(out, fielder.Field())
 		}
@@ -76,6 +157,16 @@ func Float64(name string, value float64) FieldFloat64 {
 	return FieldFloat64{Name: name, Value: value}
 }
 
+// Lazy adds a field whose value is computed by calling fn, but only if the line is actually
+// logged (i.e. it isn't filtered out by a min level). This is true even when passed to WithFields,
+// in which case fn is called once for each line that is logged.
+// The name of the Fielder returned by fn is replaced with the name passed to Lazy.
+// Note that fn may be called from multiple goroutines, and may be called more than once per line
+// if multiple root loggers are involved (e.g. via a TeeLogger).
+func Lazy(name string, fn func() Fielder) FieldLazy {
+	return FieldLazy{Name: name, Fn: fn}
+}
+
 // Int adds a signed integer field
 func Int(name string, value int) FieldInt64 {
 	return FieldInt64{Name: name, Value: int64(value)}
@@ -208,7 +299,7 @@ type FieldDuration struct {
 }
 
 func (f FieldDuration) Field() Field {
-	return Field{Name: f.Name, Value: f.Value.String(), IsJSONString: true, IsJSONSafe: true}
+	return Field{Name: f.Name, Value: f.Value.String(), IsJSONString: true, IsJSONSafe: true, Kind: KindDuration}
 }
 
 // Error
@@ -222,7 +313,22 @@ func (f FieldError) Field() Field {
 	if f.Value == nil {
 		return Field{Name: f.Name, Value: "null"}
 	}
-	return Field{Name: f.Name, Value: f.Value.Error(), IsJSONString: true}
+	return Field{Name: f.Name, Value: errorString(f.Value), IsJSONString: true, Kind: KindError}
+}
+
+// errorString calls err.Error(), but recovers from any panic (e.g. from a nil pointer receiver),
+// and renders it the way fmt would.
+func errorString(err error) (s string) {
+	defer func() {
+		if r := recover(); r != nil {
+			if v := reflect.ValueOf(err); v.Kind() == reflect.Ptr && v.IsNil() {
+				s = "<nil>"
+				return
+			}
+			s = fmt.Sprintf("<PANIC=%v>", r)
+		}
+	}()
+	return err.Error()
 }
 
 // Float32
@@ -299,6 +405,26 @@ func (f FieldTimeUnixNano) Field() Field {
 	return Field{Name: f.Name, Value: strconv.FormatInt(f.Value.UnixNano(), 10)}
 }
 
+// Lazy
+
+type FieldLazy struct {
+	Name string
+	Fn   func() Fielder
+}
+
+func (f FieldLazy) Field() Field {
+	var fielder Fielder
+	if f.Fn != nil {
+		fielder = f.Fn()
+	}
+	if fielder == nil {
+		return Field{Name: f.Name, Value: "null"}
+	}
+	out := fielder.Field()
+	out.Name = f.Name
+	return out
+}
+
 // Uint, Uint8, Uit16, Uint32, Uint64, Byte
 
 type FieldUint64 struct {
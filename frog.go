@@ -1,6 +1,7 @@
 package frog
 
 import (
+	"fmt"
 	"io"
 	"os"
 
@@ -27,36 +28,64 @@ func HasTerminal(w io.Writer) bool {
 }
 
 // New creates a Logger that writes to os.Stdout, depending on the NewLogger type passed to it:
-// - Auto - if terminal detected on stdout, then colors and anchored lines are supported (else, uses Basic)
+// - Auto - if terminal detected on stdout, then colors and anchored lines are supported (else, uses
+// AutoUnbuffered if colors are forced via POColor or the environment, or Basic if not)
 // - AutoUnbuffered - includes colors, no anchored lines, no buffering
 // - Basic - no colors or anchored lines, no buffering
 // - JSON - no colors or anchored lines, no buffering, and each line is a valid JSON object
 // Resulting Logger can be modified by including 1 or more NewOpts after the NewLogger type.
+// Text-based Loggers use the theme in the FROG_PALETTE environment variable (see ThemeFromEnv), if
+// set, and log a warning if it is invalid. Colors can be disabled or forced via POColor, or via the
+// environment (see ColorModeFromEnv).
 // The caller is responsible for calling Close() when done with the returned Logger.
 func New(t NewLogger, opts ...PrinterOption) RootLogger {
 	hasTerminal := false
 	if t == Auto {
 		hasTerminal = HasTerminal(os.Stdout)
 		if !hasTerminal {
-			t = Basic
+			if colorsForced(opts) {
+				t = AutoUnbuffered
+			} else {
+				t = Basic
+			}
 		}
 	}
 
+	// a theme from the environment comes before any passed in options, so they can override it
+	theme, hasTheme, themeErr := ThemeFromEnv()
+
+	var log RootLogger
 	switch t {
 	case Auto:
 		prn := TextPrinter{palette: DefaultPalette.toANSI(), printTime: true, printLevel: true, fieldIndent: 20}
-		return NewBuffered(os.Stdout, hasTerminal, prn.SetOptions(opts...))
+		if hasTheme {
+			prn.SetOptions(POTheme(theme))
+		}
+		log = NewBuffered(os.Stdout, hasTerminal, prn.SetOptions(opts...))
 	case AutoUnbuffered:
 		prn := TextPrinter{palette: DefaultPalette.toANSI(), printTime: true, printLevel: true, fieldIndent: 20}
-		return NewUnbuffered(os.Stdout, prn.SetOptions(opts...))
+		if hasTheme {
+			prn.SetOptions(POTheme(theme))
+		}
+		log = NewUnbuffered(os.Stdout, prn.SetOptions(opts...))
 	case Basic:
 		prn := TextPrinter{printTime: true, printLevel: true, fieldIndent: 20}
-		return NewUnbuffered(os.Stdout, prn.SetOptions(opts...))
+		if hasTheme {
+			// no colors, but still use any custom labels
+			prn.SetOptions(poLevelLabels{Labels: theme.Labels})
+		}
+		log = NewUnbuffered(os.Stdout, prn.SetOptions(opts...))
 	case JSON:
-		return NewUnbuffered(os.Stdout, &JSONPrinter{})
+		prn := JSONPrinter{}
+		return NewUnbuffered(os.Stdout, prn.SetOptions(opts...))
+	default:
+		return nil
 	}
 
-	return nil
+	if themeErr != nil {
+		log.Warning("ignoring invalid theme", Err(themeErr))
+	}
+	return log
 }
 
 // AddAnchor adds a new logger on an anchored line (if supported).
@@ -139,3 +168,100 @@ func WithOptions(log Logger, opts ...PrinterOption) Logger {
 func WithOptionsAndFields(log Logger, opts []PrinterOption, fielders []Fielder) Logger {
 	return newCustomizerLogger(log, opts, fielders)
 }
+
+// WithRedaction creates a new Logger that wraps the passed Logger, redacting any field values
+// that match the passed in rules before they reach the Printer. This includes fields added by
+// the passed Logger and its parents (e.g. via WithFields).
+func WithRedaction(log Logger, rules ...RedactRule) Logger {
+	return &RedactingLogger{
+		parent: log,
+		rules:  append([]RedactRule(nil), rules...),
+	}
+}
+
+// Recover is meant to be deferred (e.g. `defer frog.Recover(log)`), and when the goroutine is
+// panicking, it logs the panic value and a stack trace at Error level, then flushes the root
+// Logger's buffered output, and finally re-panics with the original value.
+// The root Logger is left open, so other goroutines can keep logging to it (e.g. if the panic is
+// recovered further up the stack).
+func Recover(log Logger) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	WithOptions(log, POStackTrace(true)).Error("recovered from panic", String("panic", fmt.Sprint(r)))
+	flushRoot(log)
+	panic(r)
+}
+
+// DefaultFatalExitCode is the exit code used after a Fatal line is logged, unless the root Logger
+// was created with a different one (see ROFatalExitCode).
+const DefaultFatalExitCode = 1
+
+// exit is called to exit after a Fatal line is logged (tests replace it to avoid exiting).
+var exit = os.Exit
+
+// fatalExit closes the root Logger (to flush any buffered output), then exits with the root's
+// fatal exit code.
+func fatalExit(log Logger) {
+	code := fatalExitCode(log)
+	closeRoot(log)
+	exit(code)
+}
+
+// fatalExitCoder is implemented by root Loggers that were created with a fatal exit code
+type fatalExitCoder interface {
+	fatalExitCode() int
+}
+
+// fatalExitCode walks up the chain of parents to the first RootLogger, and returns its fatal exit
+// code (or DefaultFatalExitCode, if it doesn't have one).
+func fatalExitCode(log Logger) int {
+	for log != nil {
+		if fec, ok := log.(fatalExitCoder); ok {
+			return fec.fatalExitCode()
+		}
+		if _, ok := log.(RootLogger); ok {
+			break
+		}
+		log = Parent(log)
+	}
+	return DefaultFatalExitCode
+}
+
+// closeRoot walks up the chain of parents and closes the first RootLogger it finds. If it passes
+// through a TeeLogger, then the root of the TeeLogger's Secondary is closed as well.
+func closeRoot(log Logger) {
+	for log != nil {
+		if tee, ok := log.(*TeeLogger); ok {
+			closeRoot(tee.Secondary)
+		}
+		if root, ok := log.(RootLogger); ok {
+			root.Close()
+			return
+		}
+		log = Parent(log)
+	}
+}
+
+// flusher is implemented by root Loggers that can flush their output without closing
+type flusher interface {
+	Flush()
+}
+
+// flushRoot walks up the chain of parents (including both sides of any TeeLogger), and flushes
+// the root Logger(s) it finds.
+func flushRoot(log Logger) {
+	for log != nil {
+		if tee, ok := log.(*TeeLogger); ok {
+			flushRoot(tee.Secondary)
+		}
+		if root, ok := log.(RootLogger); ok {
+			if f, ok := root.(flusher); ok {
+				f.Flush()
+			}
+			return
+		}
+		log = Parent(log)
+	}
+}
@@ -5,15 +5,26 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
 
 var update = flag.Bool("update", false, "update golden files")
 
+func TestMain(m *testing.M) {
+	// the color settings of whoever runs the tests (e.g. NO_COLOR or FORCE_COLOR in CI) must not
+	// change the output that the tests compare against
+	for _, name := range []string{"NO_COLOR", "FORCE_COLOR", "CLICOLOR", "CLICOLOR_FORCE", EnvVarPalette} {
+		os.Unsetenv(name) //nolint:errcheck
+	}
+	os.Exit(m.Run())
+}
+
 func AssertGolden(t *testing.T, testName string, actual []byte) {
 	t.Helper()
 	golden := filepath.Join("testdata", testName+".golden")
@@ -29,6 +40,81 @@ func AssertGolden(t *testing.T, testName string, actual []byte) {
 	}
 }
 
+// setEnv sets an env var, and returns a func that restores its original value (e.g.
+// `defer setEnv("TERM", "dumb")()`).
+func setEnv(name, value string) (restore func()) {
+	restore = envRestorer(name)
+	os.Setenv(name, value) //nolint:errcheck
+	return restore
+}
+
+// unsetEnv unsets an env var, and returns a func that restores its original value.
+func unsetEnv(name string) (restore func()) {
+	restore = envRestorer(name)
+	os.Unsetenv(name) //nolint:errcheck
+	return restore
+}
+
+func envRestorer(name string) func() {
+	orig, ok := os.LookupEnv(name)
+	return func() {
+		if ok {
+			os.Setenv(name, orig) //nolint:errcheck
+		} else {
+			os.Unsetenv(name) //nolint:errcheck
+		}
+	}
+}
+
+// testTime is the time that newTestJSONPrinter's clock is stuck at
+var testTime = time.Date(2019, 9, 10, 21, 44, 0, 0, time.UTC)
+
+// newTestJSONPrinter returns a JSONPrinter whose clock is stuck at testTime, with the passed in
+// options applied after the clock.
+func newTestJSONPrinter(opts ...PrinterOption) Printer {
+	prn := JSONPrinter{}
+	prn.SetOptions(POClock(NewManualClock(testTime)))
+	return prn.SetOptions(opts...)
+}
+
+// testJSONLine returns a line as rendered by newTestJSONPrinter. The msg must not need escaping,
+// and fields is the JSON of any fields (without a leading comma).
+func testJSONLine(level, msg, fields string) string {
+	line := `{"timestamp":"2019-09-10T21:44:00Z","level":"` + level + `","msg":"` + msg + `"`
+	if len(fields) > 0 {
+		line += "," + fields
+	}
+	return line + "}"
+}
+
+// testOutput calls logLine with a Logger that uses a TextPrinter, then again with a Logger that
+// uses a JSONPrinter (each with the passed in options), and compares the output of each to the
+// expected values. The line is expected to be logged at Info with an empty message, so only the
+// fields of the JSON output are compared.
+func testOutput(t *testing.T, opts []PrinterOption, logLine func(Logger), expectedText, expectedJSON string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logLine(NewUnbuffered(&buf, (&TextPrinter{}).SetOptions(opts...)))
+	if actual := strings.TrimSpace(buf.String()); actual != expectedText {
+		t.Errorf("text:\nexpected: %s\nactual:   %s", expectedText, actual)
+	}
+
+	buf.Reset()
+	logLine(NewUnbuffered(&buf, newTestJSONPrinter(opts...)))
+	expected := testJSONLine("info", "", expectedJSON)
+	if actual := strings.TrimSpace(buf.String()); actual != expected {
+		t.Errorf("json:\nexpected: %s\nactual:   %s", expected, actual)
+	}
+}
+
+// testFieldOutput logs the fielder with both a TextPrinter and a JSONPrinter, and compares the
+// output of each to the expected values (see testOutput).
+func testFieldOutput(t *testing.T, fielder Fielder, expectedText, expectedJSON string) {
+	t.Helper()
+	testOutput(t, nil, func(log Logger) { log.Info("", fielder) }, expectedText, expectedJSON)
+}
+
 func Test_BufferedLogger(t *testing.T) {
 	cases := []struct {
 		Name   string
@@ -43,6 +129,7 @@ func Test_BufferedLogger(t *testing.T) {
 		{"with-fields-and-opts", withFieldsAndOptions},
 		{"with-fields-and-anchors", withFieldsAndAnchors},
 		{"with-quotes", withQuotes},
+		{"anchors-stack-trace", stackTraceWithAnchors},
 	}
 
 	for _, tc := range cases {
@@ -151,7 +238,7 @@ func Test_JSONPrinter(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.Name+".json", func(t *testing.T) {
 			var buf bytes.Buffer
-			l := NewUnbuffered(&buf, &JSONPrinter{TimeOverride: time.Date(2019, 9, 10, 21, 44, 0, 0, time.UTC)})
+			l := NewUnbuffered(&buf, newTestJSONPrinter())
 			tc.DoWork(l)
 			l.Close()
 			AssertGolden(t, tc.Name+".json", buf.Bytes())
@@ -279,6 +366,158 @@ func Test_TeeLogger(t *testing.T) {
 	}
 }
 
+func Test_Recover(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewBuffered(&buf, false, &TextPrinter{printLevel: true})
+
+	fnPanics := func() {
+		defer Recover(WithFields(log, String("where", "fnPanics")))
+		panic("oh no")
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != "oh no" {
+				t.Errorf("expected Recover to re-panic with the original value, got %v", r)
+			}
+		}()
+		fnPanics()
+	}()
+
+	// Recover should have flushed the root logger, so all output should already be written
+	lines := strings.Split(buf.String(), "\n")
+	if expected := "[ERR] recovered from panic     where=fnPanics panic=\"oh no\""; lines[0] != expected {
+		t.Errorf("expected first line to be %q, got %q", expected, lines[0])
+	}
+	if !strings.Contains(buf.String(), "Test_Recover.func1\n") {
+		t.Errorf("expected stack trace to include the panicking function, got:\n%s", buf.String())
+	}
+
+	// the root logger should still be open, since the panic was recovered
+	log.Info("still logging")
+	log.Close()
+	if !strings.HasSuffix(buf.String(), "\n[nfo] still logging\n") {
+		t.Errorf("expected to keep logging after Recover, got:\n%s", buf.String())
+	}
+}
+
+func Test_Fatal(t *testing.T) {
+	var exitCodes []int
+	origExit := exit
+	exit = func(code int) { exitCodes = append(exitCodes, code) }
+	defer func() { exit = origExit }()
+
+	var buf bytes.Buffer
+	log := NewBuffered(&buf, false, &TextPrinter{printLevel: true})
+	WithFields(log, String("where", "child")).Fatal("giving up")
+
+	// Fatal should have closed the root logger, so all output should already be flushed
+	if expected := "[FTL] giving up      where=child\n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	log2 := NewUnbuffered(&buf, &TextPrinter{printLevel: true}, ROFatalExitCode(3))
+	Named(log2, "child").Log(Fatal, "via Log")
+	log2.Log(Error, "not fatal")
+	(&NullLogger{}).Fatal("discarded")
+
+	if expected := "[FTL] child: via Log\n[ERR] not fatal\n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+	if len(exitCodes) != 3 || exitCodes[0] != 1 || exitCodes[1] != 3 || exitCodes[2] != 1 {
+		t.Errorf("expected exit codes [1 3 1], got %v", exitCodes)
+	}
+}
+
+// Test_ConcurrentMinLevels is meant to be run with -race, to check that min levels can be changed
+// while other goroutines are logging through every kind of Logger.
+func Test_ConcurrentMinLevels(t *testing.T) {
+	var buf bytes.Buffer
+	buffered := NewBuffered(&buf, false, &TextPrinter{printLevel: true})
+	unbuffered := NewUnbuffered(ioutil.Discard, &JSONPrinter{})
+	tee, closeTee := NewRootTee(buffered, unbuffered)
+
+	fields := WithFields(tee, Int("n", 1))
+	named := Named(fields, "db")
+	redacted := WithRedaction(named, RedactName("password"))
+	anchored := AddAnchor(redacted)
+	// an AnchoredLogger whose anchor was removed, which logs to its parent instead
+	removedAnchor := AddAnchor(fields)
+	RemoveAnchor(removedAnchor)
+	// AddAnchor wraps Loggers whose root doesn't support anchors in a NoAnchorLogger
+	noAnchor := AddAnchor(NewUnbuffered(ioutil.Discard, &TextPrinter{}))
+	if _, ok := anchored.(*AnchoredLogger); !ok {
+		t.Fatalf("expected an AnchoredLogger, got %T", anchored)
+	}
+	if _, ok := removedAnchor.(*AnchoredLogger); !ok {
+		t.Fatalf("expected an AnchoredLogger, got %T", removedAnchor)
+	}
+	if _, ok := noAnchor.(*NoAnchorLogger); !ok {
+		t.Fatalf("expected a NoAnchorLogger, got %T", noAnchor)
+	}
+	loggers := []Logger{
+		buffered, unbuffered, tee, fields, named, redacted, anchored, removedAnchor, noAnchor, &NullLogger{},
+	}
+
+	const writers = 8
+	const lines = 200
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	// keep changing min levels (including named levels) until the writers are done
+	changed := make(chan struct{})
+	go func() {
+		defer close(changed)
+		levels := []Level{Trace, Verbose, Info, Warning, Error}
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			for j, log := range loggers {
+				log.SetMinLevel(levels[(i+j)%len(levels)])
+				_ = log.MinLevel()
+			}
+			NamedLevels(buffered).SetLevel("db.*", levels[i%len(levels)])
+		}
+	}()
+
+	wg.Add(writers)
+	for w := 0; w < writers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < lines; i++ {
+				log := loggers[(w+i)%len(loggers)]
+				log.Log(Level(i%int(Fatal)), "line", Int("writer", w), String("password", "hunter2"))
+				log.Transient("progress", Int("i", i))
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(done)
+	<-changed
+
+	// the last min levels set should still apply
+	for _, log := range loggers {
+		log.SetMinLevel(Transient)
+	}
+	NamedLevels(buffered).SetLevel("db.*", Error)
+	named.Warning("hidden")
+	named.Error("shown")
+
+	RemoveAnchor(anchored)
+	closeTee()
+	out := buf.String()
+	if strings.Contains(out, "db: hidden") || !strings.Contains(out, "[ERR] db: shown") {
+		if len(out) > 200 {
+			out = out[len(out)-200:]
+		}
+		t.Errorf("expected only the error from the named logger at the end, got:\n%s", out)
+	}
+}
+
 // helpers
 
 // logNote is meant to be used with the "DoWork" funcs
@@ -562,6 +801,24 @@ func withFieldsAndAnchors(l Logger) {
 	l.Info("after removing anchored logger")
 }
 
+func stackTraceWithAnchors(l Logger) {
+	// swap in a predictable stack, so the output doesn't depend on where the tests were run from
+	defer func(orig func(int) []string) { captureStack = orig }(captureStack)
+	captureStack = func(int) []string {
+		return []string{"main.main()", "    /src/main.go:12", "runtime.main()", "    /go/src/runtime/proc.go:250"}
+	}
+
+	l.SetMinLevel(Info)
+	la := AddAnchor(l)
+	lb := AddAnchor(l)
+	la.Transient("anchor a")
+	lb.Transient("anchor b")
+	WithOptions(l, POStackTrace(true)).Error("error with a stack trace")
+	WithOptions(la, POStackTrace(true)).Error("anchored error with a stack trace")
+	RemoveAnchor(lb)
+	RemoveAnchor(la)
+}
+
 func withQuotes(l Logger) {
 	l.SetMinLevel(Info)
 	l.Info("unquoted")
@@ -16,6 +16,18 @@ type ImplData struct {
 	// Fields holds Fielders that have already been turned into Fields. This is used by
 	// CustomizerLoggers to cache the fields that will be included with every log message.
 	Fields []Field
+
+	// Redactions holds the rules collected from any RedactingLoggers in the chain. The root Logger
+	// applies them to every field on the line (e.g. via AssembleFields), before the Printer sees them.
+	Redactions []RedactRule
+
+	// LoggerName is set by the innermost NamedLogger in the chain (see Named). The root Logger uses
+	// it to look up any min level set for that name, and AssembleFields adds it as a LoggerField.
+	LoggerName string
+
+	// ignoreMinLevel is set for lines that should be logged no matter what min levels are set along
+	// the way (see setSignalLevel).
+	ignoreMinLevel bool
 }
 
 // MergeMinLevel sets MinLevel to the max of its own MinLevel and the passed in Level.
@@ -32,3 +44,30 @@ func (d *ImplData) MergeFields(fields []Field) {
 	}
 	d.Fields = append(fields, d.Fields...)
 }
+
+// MergeRedactions adds any passed in rules before the existing rules
+func (d *ImplData) MergeRedactions(rules []RedactRule) {
+	if len(rules) == 0 {
+		return
+	}
+	d.Redactions = append(rules[:len(rules):len(rules)], d.Redactions...)
+}
+
+// AssembleFields is meant to be called by a root Logger once it has decided to keep a line. It
+// combines the LoggerName (if any) and the cached Fields with the passed in fielders (see
+// FieldifyAndAppend), then applies any Redactions.
+func (d *ImplData) AssembleFields(fielders []Fielder) []Field {
+	cached := d.Fields
+	if len(d.LoggerName) > 0 {
+		cached = make([]Field, 1, 1+len(d.Fields))
+		cached[0] = Field{Name: LoggerField, Value: d.LoggerName, IsJSONString: true, isLoggerName: true}
+		cached = append(cached, d.Fields...)
+	}
+	fields := FieldifyAndAppend(cached, fielders)
+	if len(d.Redactions) > 0 {
+		for i := range fields {
+			fields[i] = redactField(fields[i], d.Redactions)
+		}
+	}
+	return fields
+}
@@ -1,13 +1,21 @@
 package frog
 
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
 type Level byte
 
 const (
 	Transient Level = iota // strictly unimportant, ie progress bars, real-time byte counts, estimated time remaining, etc
+	Trace                  // very detailed debugging info, ie wire-level tracing
 	Verbose                // debugging info
 	Info                   // normal message
 	Warning                // something unusual happened
 	Error                  // something bad happened
+	Fatal                  // something so bad happened that the process must exit
 
 	levelMax
 	levelMin Level = 0
@@ -17,6 +25,8 @@ func (l Level) String() string {
 	switch l {
 	case Transient:
 		return "transient"
+	case Trace:
+		return "trace"
 	case Verbose:
 		return "verbose"
 	case Info:
@@ -25,6 +35,146 @@ func (l Level) String() string {
 		return "warning"
 	case Error:
 		return "error"
+	case Fatal:
+		return "fatal"
 	}
 	return ""
 }
+
+// atomicLevel holds a Level that is safe to read and write from multiple goroutines (e.g. to change
+// a Logger's min level while other goroutines are logging through it). The zero value is Transient.
+type atomicLevel struct {
+	v int32 // to keep thread safe, use atomic reads/writes
+}
+
+func (a *atomicLevel) Load() Level {
+	return Level(atomic.LoadInt32(&a.v))
+}
+
+func (a *atomicLevel) Store(level Level) {
+	atomic.StoreInt32(&a.v, int32(level))
+}
+
+// ParseLevel returns the Level with the passed in name, ignoring case and surrounding spaces.
+// Names can be any of those returned by Level.String (e.g. "warning"), TextPrinter's default
+// abbreviations with or without brackets (e.g. "wrn" or "[WRN]"), or the common aliases "debug"
+// (Verbose) and "warn" (Warning).
+func ParseLevel(name string) (Level, error) {
+	s := strings.ToLower(strings.TrimSpace(name))
+	for l := levelMin; l < levelMax; l++ {
+		label := strings.ToLower(defaultLevelLabels[l])
+		if s == l.String() || s == label || s == strings.Trim(label, "[]") {
+			return l, nil
+		}
+	}
+	switch s {
+	case "debug":
+		return Verbose, nil
+	case "warn":
+		return Warning, nil
+	}
+	return 0, fmt.Errorf("frog: unknown level %q (expected one of: %s)", name, strings.Join(levelNames(), ", "))
+}
+
+// levelNames returns the name of each level, from lowest to highest
+func levelNames() []string {
+	names := make([]string, 0, levelMax)
+	for l := levelMin; l < levelMax; l++ {
+		names = append(names, l.String())
+	}
+	return names
+}
+
+// MarshalText implements encoding.TextMarshaler, using the names from Level.String.
+func (l Level) MarshalText() ([]byte, error) {
+	if l >= levelMax {
+		return nil, fmt.Errorf("frog: unable to marshal unknown level %d", l)
+	}
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting any name that ParseLevel accepts.
+func (l *Level) UnmarshalText(text []byte) error {
+	parsed, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = parsed
+	return nil
+}
+
+// Set implements flag.Value (along with String), accepting any name that ParseLevel accepts, e.g.:
+//
+//	level := frog.Info
+//	flag.Var(&level, "level", "minimum level to log")
+func (l *Level) Set(name string) error {
+	return l.UnmarshalText([]byte(name))
+}
+
+// LevelLabels are the labels used to display each level. Printers leave any empty labels as their
+// default (see POLevelLabels).
+type LevelLabels [levelMax]string
+
+// defaultLevelLabels are used by TextPrinter when no other label is set
+var defaultLevelLabels = LevelLabels{
+	Transient: "[==>]",
+	Trace:     "[trc]",
+	Verbose:   "[dbg]",
+	Info:      "[nfo]",
+	Warning:   "[WRN]",
+	Error:     "[ERR]",
+	Fatal:     "[FTL]",
+}
+
+// Level label presets, for use with POLevelLabels.
+var (
+	// LevelLabelsDefault are TextPrinter's default labels (e.g. "[nfo]").
+	LevelLabelsDefault = defaultLevelLabels
+	// LevelLabelsWords are the full name of each level (e.g. "info").
+	LevelLabelsWords = LevelLabels{
+		Transient: "transient",
+		Trace:     "trace",
+		Verbose:   "verbose",
+		Info:      "info",
+		Warning:   "warning",
+		Error:     "error",
+		Fatal:     "fatal",
+	}
+	// LevelLabelsUpper are fixed-width uppercase names (e.g. "INFO ").
+	LevelLabelsUpper = LevelLabels{
+		Transient: "TRANS",
+		Trace:     "TRACE",
+		Verbose:   "DEBUG",
+		Info:      "INFO ",
+		Warning:   "WARN ",
+		Error:     "ERROR",
+		Fatal:     "FATAL",
+	}
+	// LevelLabelsEmoji are a single emoji per level (e.g. "💬").
+	LevelLabelsEmoji = LevelLabels{
+		Transient: "⏳",
+		Trace:     "🔍",
+		Verbose:   "🐛",
+		Info:      "💬",
+		Warning:   "🚧",
+		Error:     "🔥",
+		Fatal:     "💀",
+	}
+)
+
+// set replaces any labels that have a non-empty label in the passed in labels.
+func (l *LevelLabels) set(labels LevelLabels) {
+	for i, label := range labels {
+		if len(label) > 0 {
+			l[i] = label
+		}
+	}
+}
+
+// label returns the label for the passed in level, or def if the label is empty.
+func (l *LevelLabels) label(level Level, def string) string {
+	if level < levelMax && len(l[level]) > 0 {
+		return l[level]
+	}
+	return def
+}
@@ -1,6 +1,13 @@
 package frog
 
-import "testing"
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
 
 func Test_LevelStrings(t *testing.T) {
 	usedLevels := make(map[string]int)
@@ -17,3 +24,161 @@ func Test_LevelStrings(t *testing.T) {
 		usedLevels[str] = int(l)
 	}
 }
+
+func Test_LevelOrder(t *testing.T) {
+	expected := []Level{Transient, Trace, Verbose, Info, Warning, Error, Fatal}
+	for i := 1; i < len(expected); i++ {
+		if expected[i-1] >= expected[i] {
+			t.Errorf("expected %v to be below %v", expected[i-1], expected[i])
+		}
+	}
+
+	var buf bytes.Buffer
+	log := NewUnbuffered(&buf, &TextPrinter{printLevel: true})
+	log.SetMinLevel(Trace)
+	log.Transient("transient")
+	log.Trace("trace")
+	log.Verbose("verbose")
+	log.SetMinLevel(Verbose)
+	log.Trace("hidden")
+	if expected := "[trc] trace\n[dbg] verbose\n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func Test_LevelLabelPresets(t *testing.T) {
+	presets := map[string]LevelLabels{
+		"default": LevelLabelsDefault,
+		"words":   LevelLabelsWords,
+		"upper":   LevelLabelsUpper,
+		"emoji":   LevelLabelsEmoji,
+	}
+	for name, labels := range presets {
+		for l := levelMin; l < levelMax; l++ {
+			if len(labels[l]) == 0 {
+				t.Errorf("%s: level %v has no label", name, l)
+			}
+		}
+	}
+	for l := levelMin; l < levelMax; l++ {
+		if len(LevelLabelsUpper[l]) != len(LevelLabelsUpper[levelMin]) {
+			t.Errorf("upper: expected fixed width labels, but %q and %q differ", LevelLabelsUpper[l], LevelLabelsUpper[levelMin])
+		}
+	}
+}
+
+func Test_POLevelLabels(t *testing.T) {
+	var labels LevelLabels
+	labels[Info] = "INFO "
+	labels[Warning] = "⚠"
+
+	var buf bytes.Buffer
+	log := NewUnbuffered(&buf, (&TextPrinter{printLevel: true}).SetOptions(POLevelLabels(labels)))
+	log.Info("hello")
+	log.Warning("careful")
+	log.Error("unchanged")
+	log.Close()
+	expected := "INFO  hello\n⚠ careful\n[ERR] unchanged\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("text:\nexpected: %q\nactual:   %q", expected, actual)
+	}
+
+	buf.Reset()
+	log = NewUnbuffered(&buf, newTestJSONPrinter(POLevelLabels(labels)))
+	log.Info("hello")
+	log.Warning("careful")
+	log.Error("unchanged")
+	log.Close()
+	expected = strings.Join([]string{
+		testJSONLine("INFO", "hello", ""),
+		testJSONLine("⚠", "careful", ""),
+		testJSONLine("error", "unchanged", ""),
+	}, "\n") + "\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("json:\nexpected: %s\nactual:   %s", expected, actual)
+	}
+}
+
+func Test_ParseLevel(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Expected Level
+	}{
+		{"transient", Transient},
+		{"==>", Transient},
+		{"trace", Trace},
+		{"TRC", Trace},
+		{"verbose", Verbose},
+		{"dbg", Verbose},
+		{"debug", Verbose},
+		{" Info ", Info},
+		{"nfo", Info},
+		{"[nfo]", Info},
+		{"WARNING", Warning},
+		{"wrn", Warning},
+		{"warn", Warning},
+		{"error", Error},
+		{"[ERR]", Error},
+		{"fatal", Fatal},
+		{"ftl", Fatal},
+	}
+	for _, tc := range cases {
+		actual, err := ParseLevel(tc.Name)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.Name, err)
+		} else if actual != tc.Expected {
+			t.Errorf("%q: expected %v, got %v", tc.Name, tc.Expected, actual)
+		}
+	}
+
+	for _, name := range []string{"", "infos", "[]", "5"} {
+		if _, err := ParseLevel(name); err == nil {
+			t.Errorf("%q: expected an error", name)
+		}
+	}
+}
+
+func Test_LevelMarshalText(t *testing.T) {
+	type config struct {
+		Level Level `json:"level"`
+	}
+	b, err := json.Marshal(config{Level: Warning})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"level":"warning"}`; string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, b)
+	}
+
+	var c config
+	if err := json.Unmarshal([]byte(`{"level":"dbg"}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Level != Verbose {
+		t.Errorf("expected %v, got %v", Verbose, c.Level)
+	}
+	if err := json.Unmarshal([]byte(`{"level":"nope"}`), &c); err == nil {
+		t.Errorf("expected an error for an unknown level")
+	}
+
+	if _, err := levelMax.MarshalText(); err == nil {
+		t.Errorf("expected an error when marshalling an unknown level")
+	}
+}
+
+func Test_LevelFlag(t *testing.T) {
+	level := Info
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Var(&level, "level", "minimum level to log")
+
+	if err := fs.Parse([]string{"-level", "trace"}); err != nil {
+		t.Fatal(err)
+	}
+	if level != Trace {
+		t.Errorf("expected %v, got %v", Trace, level)
+	}
+	if err := fs.Parse([]string{"-level", "loud"}); err == nil {
+		t.Errorf("expected an error for an unknown level")
+	}
+}
@@ -0,0 +1,135 @@
+package frog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// LevelHandler returns an http.Handler that reports and changes the min level of the passed in
+// Logger (usually a root Logger), along with the min levels of named Loggers (see NamedLevels).
+//
+// GET responds with the current levels as JSON, e.g.:
+//
+//	{"level":"info","named":{"db.*":"verbose"}}
+//
+// PUT accepts the same JSON, where each part is optional, and a null named level removes it:
+//
+//	{"level":"verbose","named":{"db.*":"trace","http":null}}
+//
+// and then responds with the new levels. Levels can be anything that ParseLevel accepts. If any
+// part of the request is invalid, nothing is changed, and it responds with 400 Bad Request.
+func LevelHandler(log Logger) http.Handler {
+	return &levelHandler{log: log}
+}
+
+type levelHandler struct {
+	log Logger
+}
+
+// levelState is the JSON body of LevelHandler's responses
+type levelState struct {
+	Level Level            `json:"level"`
+	Named map[string]Level `json:"named,omitempty"`
+}
+
+// levelRequest is the JSON body of a PUT to LevelHandler
+type levelRequest struct {
+	Level *Level            `json:"level"`
+	Named map[string]*Level `json:"named"`
+}
+
+// maxLevelRequestSize limits how much of a request body LevelHandler will read
+const maxLevelRequestSize = 64 * 1024
+
+func (h *levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if err := h.update(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	state := levelState{Level: h.log.MinLevel()}
+	if registry := NamedLevels(h.log); registry != nil {
+		state.Named = registry.Levels()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(state)
+}
+
+// update validates the whole request before changing anything
+func (h *levelHandler) update(body io.Reader) error {
+	var req levelRequest
+	dec := json.NewDecoder(io.LimitReader(body, maxLevelRequestSize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		return fmt.Errorf("frog: invalid level request: %w", err)
+	}
+
+	registry := NamedLevels(h.log)
+	if len(req.Named) > 0 {
+		if registry == nil {
+			return fmt.Errorf("frog: named levels are not supported by this logger")
+		}
+		for pattern := range req.Named {
+			if err := validateNamePattern(pattern); err != nil {
+				return err
+			}
+		}
+	}
+	if req.Level != nil && *req.Level >= levelMax {
+		return fmt.Errorf("frog: unknown level %d", *req.Level)
+	}
+
+	if req.Level != nil {
+		h.log.SetMinLevel(*req.Level)
+	}
+	for pattern, level := range req.Named {
+		if level == nil {
+			registry.ClearLevel(pattern)
+		} else {
+			registry.SetLevel(pattern, *level)
+		}
+	}
+	return nil
+}
+
+// levelSignalMin and levelSignalMax are the range of min levels cycled through by
+// HandleLevelSignals (Transient is skipped, as it only differs from Trace for lines that aren't
+// anchored, and Fatal is skipped, as it would hide errors).
+const (
+	levelSignalMin = Trace
+	levelSignalMax = Error
+)
+
+// cycleLevel returns the next min level down (more verbose), or up (less verbose), wrapping around
+// at the ends of the range that HandleLevelSignals cycles through.
+func cycleLevel(level Level, down bool) Level {
+	switch {
+	case level < levelSignalMin || level > levelSignalMax:
+		return Info
+	case down && level == levelSignalMin:
+		return levelSignalMax
+	case down:
+		return level - 1
+	case level == levelSignalMax:
+		return levelSignalMin
+	default:
+		return level + 1
+	}
+}
+
+// setSignalLevel sets the min level, then logs the change at Info level, even if the new min level
+// (or that of any parent Logger) would normally filter it out.
+func setSignalLevel(log Logger, level Level) {
+	log.SetMinLevel(level)
+	log.LogImpl(Info, "min level changed", []Fielder{String("level", level.String())}, nil, ImplData{ignoreMinLevel: true})
+}
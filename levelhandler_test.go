@@ -0,0 +1,130 @@
+package frog
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_LevelHandler(t *testing.T) {
+	log := NewUnbuffered(ioutil.Discard, &TextPrinter{})
+	srv := httptest.NewServer(LevelHandler(log))
+	defer srv.Close()
+
+	do := func(method, body string) (int, string) {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, strings.TrimSpace(string(b))
+	}
+
+	cases := []struct {
+		Name           string
+		Method         string
+		Body           string
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		{"get", "GET", "", 200, `{"level":"info"}`},
+		{"set level", "PUT", `{"level":"verbose"}`, 200, `{"level":"verbose"}`},
+		{"set abbreviation", "PUT", `{"level":"WRN"}`, 200, `{"level":"warning"}`},
+		{"set named", "PUT", `{"named":{"db.*":"trace","http":"error"}}`, 200, `{"level":"warning","named":{"db.*":"trace","http":"error"}}`},
+		{"set both", "PUT", `{"level":"info","named":{"db.*":"dbg"}}`, 200, `{"level":"info","named":{"db.*":"verbose","http":"error"}}`},
+		{"clear named", "PUT", `{"named":{"http":null}}`, 200, `{"level":"info","named":{"db.*":"verbose"}}`},
+		{"bad json", "PUT", `{"level":`, 400, ``},
+		{"unknown field", "PUT", `{"lvl":"info"}`, 400, ``},
+		{"bad level", "PUT", `{"level":"trace","named":{"http":"loud"}}`, 400, ``},
+		{"bad pattern", "PUT", `{"level":"trace","named":{"*.db":"info"}}`, 400, ``},
+		{"unchanged after errors", "GET", "", 200, `{"level":"info","named":{"db.*":"verbose"}}`},
+		{"bad method", "POST", `{"level":"info"}`, 405, `method not allowed`},
+	}
+	for _, tc := range cases {
+		status, body := do(tc.Method, tc.Body)
+		if status != tc.ExpectedStatus {
+			t.Errorf("%s: expected status %d, got %d (%s)", tc.Name, tc.ExpectedStatus, status, body)
+			continue
+		}
+		if len(tc.ExpectedBody) > 0 && body != tc.ExpectedBody {
+			t.Errorf("%s:\nexpected: %s\nactual:   %s", tc.Name, tc.ExpectedBody, body)
+		}
+	}
+
+	if log.MinLevel() != Info {
+		t.Errorf("expected the logger's min level to be changed, got %v", log.MinLevel())
+	}
+	if level, ok := NamedLevels(log).Match("db.pool"); !ok || level != Verbose {
+		t.Errorf("expected db.pool to be verbose, got %v, %v", level, ok)
+	}
+}
+
+func Test_LevelHandlerWithoutRegistry(t *testing.T) {
+	log := (&NullLogger{}).SetMinLevel(Info)
+	h := LevelHandler(log)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("PUT", "/", strings.NewReader(`{"named":{"db":"verbose"}}`)))
+	if rec.Code != 400 {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("PUT", "/", strings.NewReader(`{"level":"error"}`)))
+	if expected := `{"level":"error"}`; rec.Code != 200 || strings.TrimSpace(rec.Body.String()) != expected {
+		t.Errorf("expected 200 %s, got %d %s", expected, rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected a JSON content type, got %q", ct)
+	}
+}
+
+func Test_CycleLevel(t *testing.T) {
+	cases := []struct {
+		Level    Level
+		Down     bool
+		Expected Level
+	}{
+		{Info, true, Verbose},
+		{Verbose, true, Trace},
+		{Trace, true, Error},
+		{Info, false, Warning},
+		{Error, false, Trace},
+		{Transient, true, Info},
+		{Fatal, false, Info},
+	}
+	for _, tc := range cases {
+		if actual := cycleLevel(tc.Level, tc.Down); actual != tc.Expected {
+			t.Errorf("%v (down=%v): expected %v, got %v", tc.Level, tc.Down, tc.Expected, actual)
+		}
+	}
+}
+
+func Test_SetSignalLevel(t *testing.T) {
+	var buf bytes.Buffer
+	root := NewBuffered(&buf, false, &TextPrinter{printLevel: true})
+	root.SetMinLevel(Error)
+	child := WithFields(root, String("who", "child"))
+
+	// the change is logged at Info, even though the root (and now the child) would filter it out
+	setSignalLevel(child, Warning)
+	child.Warning("filtered")
+	root.Close()
+
+	expected := "[nfo] min level changed   who=child level=warning\n"
+	if buf.String() != expected {
+		t.Errorf("\nexpected: %q\nactual:   %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,37 @@
+//go:build !windows
+// +build !windows
+
+package frog
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// HandleLevelSignals changes the min level of the passed in Logger (usually a root Logger) whenever
+// the process receives SIGUSR1 or SIGUSR2. SIGUSR1 lowers the min level by one (e.g. from Info to
+// Verbose), and SIGUSR2 raises it by one, cycling between Trace and Error. Each change is logged.
+// Call the returned func to stop handling the signals.
+// On Windows, which doesn't have SIGUSR1 and SIGUSR2, this does nothing.
+func HandleLevelSignals(log Logger) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	go func() {
+		for {
+			select {
+			case sig := <-ch:
+				setSignalLevel(log, cycleLevel(log.MinLevel(), sig == syscall.SIGUSR1))
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
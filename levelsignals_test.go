@@ -0,0 +1,73 @@
+//go:build !windows
+// +build !windows
+
+package frog
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// lockedBuffer lets the test safely read what the signal handling goroutine logs
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func Test_HandleLevelSignals(t *testing.T) {
+	var buf lockedBuffer
+	log := NewUnbuffered(&buf, &TextPrinter{printLevel: true})
+	stop := HandleLevelSignals(log)
+	defer stop()
+
+	send := func(sig syscall.Signal, expectedLines int) {
+		t.Helper()
+		if err := syscall.Kill(syscall.Getpid(), sig); err != nil {
+			t.Fatal(err)
+		}
+		// each change is logged, so wait for the line to show up
+		deadline := time.Now().Add(5 * time.Second)
+		for strings.Count(buf.String(), "\n") < expectedLines {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for the min level to change, got:\n%s", buf.String())
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	send(syscall.SIGUSR1, 1)
+	send(syscall.SIGUSR1, 2)
+	send(syscall.SIGUSR2, 3)
+	send(syscall.SIGUSR2, 4)
+	send(syscall.SIGUSR2, 5)
+
+	expected := strings.Join([]string{
+		"[nfo] min level changed   level=verbose",
+		"[nfo] min level changed   level=trace",
+		"[nfo] min level changed   level=verbose",
+		"[nfo] min level changed   level=info",
+		"[nfo] min level changed   level=warning",
+	}, "\n") + "\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("\nexpected:\n%s\nactual:\n%s", expected, actual)
+	}
+	if log.MinLevel() != Warning {
+		t.Errorf("expected min level to be warning, got %v", log.MinLevel())
+	}
+}
@@ -0,0 +1,8 @@
+package frog
+
+// HandleLevelSignals changes the min level of the passed in Logger whenever the process receives
+// SIGUSR1 or SIGUSR2, but Windows doesn't have those signals, so it does nothing.
+// Use LevelHandler instead.
+func HandleLevelSignals(log Logger) (stop func()) {
+	return func() {}
+}
@@ -0,0 +1,160 @@
+package frog
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// LineTruncatedField is the name of the field that is added to a line that had to be shortened to
+// fit within the max line length (see POMaxLineLength). Its value is the original line length.
+const LineTruncatedField = "line_truncated"
+
+// truncatedMinKeep is the fewest bytes of a value that are kept when shortening a line. Values
+// that would have to be cut shorter than this are dropped instead.
+const truncatedMinKeep = 8
+
+// renderWithLimits enforces the max field and line lengths (if greater than 0) around a printer's
+// render func. Field values longer than maxField are truncated first. Then if the rendered line is
+// longer than maxLine, the largest field values (or the message) are truncated or dropped, and a
+// LineTruncatedField is added, until the line fits (or there is nothing left to shorten).
+// Since the line is re-rendered by the printer each time, its output format (e.g. JSON) stays valid.
+func renderWithLimits(maxLine, maxField int, msg string, fields []Field, render func(msg string, fields []Field) string) string {
+	// keep the original values around, so that truncation markers always show the original length
+	sources := fields
+	msgSource := msg
+
+	if maxField > 0 {
+		fields = truncateFields(fields, maxField)
+	}
+	line := render(msg, fields)
+	if maxLine <= 0 || len(line) <= maxLine {
+		return line
+	}
+
+	shortened := make([]Field, len(fields), len(fields)+1)
+	copy(shortened, fields)
+	sources = append([]Field(nil), sources...)
+	marker := Field{Name: LineTruncatedField, Value: strconv.Itoa(len(line))}
+
+	// every pass shortens or drops something, so this will end eventually, but cap it anyway
+	for tries := 2*len(fields) + 4; tries > 0; tries-- {
+		line = render(msg, append(shortened, marker))
+		over := len(line) - maxLine
+		if over <= 0 {
+			break
+		}
+
+		// find the longest value (with -1 meaning the message)
+		longest, longestLen := -1, len(msg)
+		for i, f := range shortened {
+			if n := fieldValueLen(f); n > longestLen {
+				longest, longestLen = i, n
+			}
+		}
+
+		sourceLen := len(msgSource)
+		if longest >= 0 {
+			sourceLen = fieldValueLen(sources[longest])
+		}
+		keep := longestLen - over - len(truncationMarker(sourceLen))
+		switch {
+		case keep >= truncatedMinKeep && longest >= 0:
+			shortened[longest] = truncateField(sources[longest], keep)
+		case keep >= truncatedMinKeep:
+			msg = truncateString(msgSource, keep)
+		case longest >= 0:
+			shortened = append(shortened[:longest], shortened[longest+1:]...)
+			sources = append(sources[:longest], sources[longest+1:]...)
+		case len(msg) > truncatedMinKeep+len(truncationMarker(sourceLen)):
+			msg = truncateString(msgSource, truncatedMinKeep)
+		default:
+			// nothing left to shorten (e.g. a long stack trace)
+			return line
+		}
+	}
+	return line
+}
+
+// truncateFields returns the fields with any values longer than max truncated. If no values were
+// too long, the passed in slice is returned unmodified, otherwise a new slice is returned.
+func truncateFields(fields []Field, max int) []Field {
+	var out []Field
+	for i, f := range fields {
+		if fieldValueLen(f) <= max {
+			continue
+		}
+		if out == nil {
+			out = make([]Field, len(fields))
+			copy(out, fields)
+		}
+		out[i] = truncateField(f, max)
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+func fieldValueLen(f Field) int {
+	if len(f.TextValue) > len(f.Value) {
+		return len(f.TextValue)
+	}
+	return len(f.Value)
+}
+
+// truncateField cuts the field's value (and text value, if any) to max bytes, and appends a
+// truncation marker. Values that are not JSON strings (e.g. objects) become JSON strings, as
+// cutting them short would otherwise make them invalid JSON.
+func truncateField(f Field, max int) Field {
+	if len(f.Value) > max {
+		f.Value = truncateString(f.Value, max)
+		if !f.IsJSONString {
+			f.IsJSONString = true
+			f.IsJSONSafe = false
+		}
+	}
+	if len(f.TextValue) > max {
+		// the marker contains a space, so the result always needs quotes
+		tv := f.TextValue
+		if len(tv) > 1 && tv[0] == '"' && tv[len(tv)-1] == '"' {
+			tv = tv[1 : len(tv)-1]
+		}
+		f.TextValue = `"` + truncateString(tv, max) + `"`
+	}
+	return f
+}
+
+// truncateString cuts s down to at most max bytes (without splitting any runes), and appends a
+// truncation marker that includes the original length.
+func truncateString(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return cutString(s, max) + truncationMarker(len(s))
+}
+
+// truncationMarker is appended to truncated values, e.g. "...(1234 bytes)"
+func truncationMarker(originalLen int) string {
+	var sb strings.Builder
+	sb.WriteString("...(")
+	sb.WriteString(strconv.Itoa(originalLen))
+	sb.WriteString(" bytes)")
+	return sb.String()
+}
+
+// cutString returns at most the first max bytes of s, backing up as needed to avoid splitting a
+// multi-byte rune.
+func cutString(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	if max <= 0 {
+		return ""
+	}
+	end := max
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end]
+}
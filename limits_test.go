@@ -0,0 +1,86 @@
+package frog
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_MaxFieldLength(t *testing.T) {
+	long := strings.Repeat("x", 100)
+	obj := JSONValue("obj", map[string]string{"body": long})
+
+	logLine := func(log Logger) {
+		log.Info("", String("s", long), String("short", "ok"), obj)
+	}
+	testOutput(t, []PrinterOption{POMaxFieldLength(10)}, logLine,
+		`s="xxxxxxxxxx...(100 bytes)" short=ok obj="map[body:x...(110 bytes)"`,
+		`"s":"xxxxxxxxxx...(100 bytes)","short":"ok","obj":"{\"body\":\"x...(111 bytes)"`,
+	)
+}
+
+func Test_MaxLineLength(t *testing.T) {
+	const max = 200
+	long := strings.Repeat("ʎ", 1000)
+	fielders := []Fielder{String("a", "small"), String("big", long), JSONValue("obj", []string{long, long}), Int("n", 42)}
+
+	clock := NewManualClock(testTime)
+	clock.SetAutoAdvance(time.Second)
+	printers := map[string]Printer{
+		"text": (&TextPrinter{printTime: true}).SetOptions(POClock(clock), POMaxLineLength(max)),
+		"json": (&JSONPrinter{}).SetOptions(POClock(clock), POMaxLineLength(max)),
+	}
+	tmpl, err := NewTemplatePrinter("{time} {msg} {fields}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	printers["template"] = tmpl.SetOptions(POClock(clock), POMaxLineLength(max))
+
+	for name, prn := range printers {
+		t.Run(name, func(t *testing.T) {
+			before := clock.Now()
+			line := prn.Render(Info, nil, strings.Repeat("m", 500), Fieldify(fielders))
+			if elapsed := clock.Now().Sub(before); elapsed != 2*time.Second {
+				t.Errorf("expected the clock to be read once per line, but it advanced %v", elapsed)
+			}
+			if len(line) > max {
+				t.Errorf("expected line to be at most %d bytes, got %d:\n%s", max, len(line), line)
+			}
+			for _, s := range []string{"a", "small", "n", "42", LineTruncatedField} {
+				if !strings.Contains(line, s) {
+					t.Errorf("expected line to contain %q:\n%s", s, line)
+				}
+			}
+			if !strings.Contains(line, "...(") {
+				t.Errorf("expected line to contain a truncation marker:\n%s", line)
+			}
+			if name == "json" {
+				var target map[string]interface{}
+				if err := json.Unmarshal([]byte(line), &target); err != nil {
+					t.Errorf("expected valid JSON, got %v:\n%s", err, line)
+				}
+			}
+		})
+	}
+}
+
+func Test_TruncateString(t *testing.T) {
+	if actual := truncateString("abc", 5); actual != "abc" {
+		t.Errorf("expected short string to be unmodified, got %q", actual)
+	}
+	if actual := truncateString("ʎʎʎ", 3); actual != "ʎ...(6 bytes)" {
+		t.Errorf("expected cut on a rune boundary, got %q", actual)
+	}
+}
+
+func Test_MaxLineLengthKeepsOriginalLength(t *testing.T) {
+	prn := (&TextPrinter{}).SetOptions(POMaxFieldLength(100), POMaxLineLength(60))
+	line := prn.Render(Info, nil, "msg", Fieldify([]Fielder{String("s", strings.Repeat("x", 5000))}))
+	if !strings.Contains(line, "...(5000 bytes)") {
+		t.Errorf("expected marker to show the original length:\n%s", line)
+	}
+	if len(line) > 60 {
+		t.Errorf("expected line to be at most 60 bytes, got %d:\n%s", len(line), line)
+	}
+}
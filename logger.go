@@ -20,6 +20,8 @@ type Logger interface {
 
 	// Transient logs a string (with optional fielders) with the log level set to Transient.
 	Transient(msg string, fielders ...Fielder) Logger
+	// Trace logs a string (with optional fielders) with the log level set to Trace.
+	Trace(msg string, fielders ...Fielder) Logger
 	// Verbose logs a string (with optional fielders) with the log level set to Verbose.
 	Verbose(msg string, fielders ...Fielder) Logger
 	// Info logs a string (with optional fielders) with the log level set to Info.
@@ -28,7 +30,11 @@ type Logger interface {
 	Warning(msg string, fielders ...Fielder) Logger
 	// Error logs a string (with optional fielders) with the log level set to Error.
 	Error(msg string, fielders ...Fielder) Logger
+	// Fatal logs a string (with optional fielders) with the log level set to Fatal, then closes the
+	// root Logger (flushing any buffered output), and exits the process (see ROFatalExitCode).
+	Fatal(msg string, fielders ...Fielder) Logger
 	// Log logs a string (with optional fielders) with the log level set to the passed in value.
+	// If the level is Fatal, it then closes the root Logger and exits, the same as Fatal.
 	Log(level Level, msg string, fielders ...Fielder) Logger
 
 	// LogImpl is called by children to pass up log events to the root Logger.
@@ -0,0 +1,287 @@
+package frog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LoggerField is the name of the field that holds the name of a named Logger (see Named).
+// TextPrinter renders it as a prefix of the message (e.g. "[nfo] db.pool: connected"), rather than
+// with the other fields.
+const LoggerField = "logger"
+
+// Named creates a new Logger that wraps the passed Logger, adding the passed in name to each line it
+// logs (see LoggerField). If the passed Logger (or one of its parents) is already named, the names
+// are joined with a dot, e.g. Named(Named(log, "db"), "pool") is named "db.pool".
+// The min level of a named Logger can also be set by name via its root's LevelRegistry (see
+// NamedLevels), without needing a reference to it.
+func Named(log Logger, name string) Logger {
+	for tmp := log; tmp != nil; tmp = Parent(tmp) {
+		if nl, ok := tmp.(*NamedLogger); ok {
+			if len(name) == 0 {
+				name = nl.name
+			} else {
+				name = nl.name + "." + name
+			}
+			break
+		}
+	}
+	return &NamedLogger{parent: log, name: name}
+}
+
+// NamedLogger is a Logger that adds its name to each line it logs (see Named).
+type NamedLogger struct {
+	parent   Logger
+	name     string
+	minLevel atomicLevel // defaults to Transient
+}
+
+// Name returns the full name of this Logger (including the names of any named parents).
+func (l *NamedLogger) Name() string {
+	return l.name
+}
+
+func (l *NamedLogger) Parent() Logger {
+	return l.parent
+}
+
+func (l *NamedLogger) MinLevel() Level {
+	return l.minLevel.Load()
+}
+
+func (l *NamedLogger) SetMinLevel(level Level) Logger {
+	l.minLevel.Store(level)
+	return l
+}
+
+func (l *NamedLogger) LogImpl(level Level, msg string, fielders []Fielder, opts []PrinterOption, d ImplData) {
+	d.MergeMinLevel(l.minLevel.Load())
+	// the innermost named logger already includes the names of any named parents
+	if len(d.LoggerName) == 0 {
+		d.LoggerName = l.name
+	}
+	l.parent.LogImpl(level, msg, fielders, opts, d)
+}
+
+func (l *NamedLogger) Transient(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Transient, msg, fielders, nil, ImplData{})
+	return l
+}
+
+func (l *NamedLogger) Trace(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Trace, msg, fielders, nil, ImplData{})
+	return l
+}
+
+func (l *NamedLogger) Verbose(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Verbose, msg, fielders, nil, ImplData{})
+	return l
+}
+
+func (l *NamedLogger) Info(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Info, msg, fielders, nil, ImplData{})
+	return l
+}
+
+func (l *NamedLogger) Warning(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Warning, msg, fielders, nil, ImplData{})
+	return l
+}
+
+func (l *NamedLogger) Error(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Error, msg, fielders, nil, ImplData{})
+	return l
+}
+
+func (l *NamedLogger) Fatal(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Fatal, msg, fielders, nil, ImplData{})
+	fatalExit(l)
+	return l
+}
+
+func (l *NamedLogger) Log(level Level, msg string, fielders ...Fielder) Logger {
+	l.LogImpl(level, msg, fielders, nil, ImplData{})
+	if level == Fatal {
+		fatalExit(l)
+	}
+	return l
+}
+
+// LevelRegistrar is the interface for loggers (e.g. Buffered and Unbuffered) that have a
+// LevelRegistry for setting the min level of named Loggers.
+type LevelRegistrar interface {
+	LevelRegistry() *LevelRegistry
+}
+
+// NamedLevels searches up the chain of parents for a LevelRegistrar (usually the root Logger), and
+// returns its LevelRegistry, or nil if there isn't one.
+func NamedLevels(log Logger) *LevelRegistry {
+	for tmp := log; tmp != nil; tmp = Parent(tmp) {
+		if lr, ok := tmp.(LevelRegistrar); ok {
+			return lr.LevelRegistry()
+		}
+	}
+	return nil
+}
+
+// LevelRegistry holds min levels for named Loggers, by name pattern. A pattern is either a full
+// name (e.g. "db.pool"), a name followed by ".*" to also match everything below that name (e.g.
+// "db.*" matches "db", "db.pool", and "db.pool.conn"), or "*" to match every named Logger.
+// When more than one pattern matches, the most specific (longest) pattern wins, with a full name
+// winning over a wildcard with the same prefix.
+// When a pattern matches a named Logger, its level replaces the root Logger's own min level for
+// that line (so it can be used to show more, or less, than the root Logger would).
+// The zero value is an empty registry, ready to use, and it is safe to use from multiple goroutines.
+type LevelRegistry struct {
+	mu     sync.RWMutex
+	levels map[string]Level
+}
+
+// SetLevel sets the min level for named Loggers that match the passed in pattern.
+func (r *LevelRegistry) SetLevel(pattern string, level Level) error {
+	pattern = strings.TrimSpace(pattern)
+	if err := validateNamePattern(pattern); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.levels == nil {
+		r.levels = make(map[string]Level)
+	}
+	r.levels[pattern] = level
+	return nil
+}
+
+// ClearLevel removes the min level set for the passed in pattern (if any).
+func (r *LevelRegistry) ClearLevel(pattern string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.levels, strings.TrimSpace(pattern))
+}
+
+// Reset removes all min levels.
+func (r *LevelRegistry) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.levels = nil
+}
+
+// Levels returns a copy of the min levels, by pattern.
+func (r *LevelRegistry) Levels() map[string]Level {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make(map[string]Level, len(r.levels))
+	for pattern, level := range r.levels {
+		out[pattern] = level
+	}
+	return out
+}
+
+// Parse sets min levels from a spec, which is a list of "pattern=level" settings separated by
+// commas, semicolons, or newlines (e.g. "db.*=verbose, http=warning"). Levels can be anything
+// that ParseLevel accepts. If any setting is invalid, no levels are changed.
+func (r *LevelRegistry) Parse(spec string) error {
+	settings := strings.FieldsFunc(spec, func(c rune) bool {
+		return c == ',' || c == ';' || c == '\n'
+	})
+	parsed := make(map[string]Level, len(settings))
+	for _, setting := range settings {
+		setting = strings.TrimSpace(setting)
+		if len(setting) == 0 {
+			continue
+		}
+		eq := strings.IndexByte(setting, '=')
+		if eq < 0 {
+			return fmt.Errorf("frog: invalid named level %q (expected \"pattern=level\")", setting)
+		}
+		pattern := strings.TrimSpace(setting[:eq])
+		if err := validateNamePattern(pattern); err != nil {
+			return err
+		}
+		level, err := ParseLevel(setting[eq+1:])
+		if err != nil {
+			return err
+		}
+		parsed[pattern] = level
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.levels == nil {
+		r.levels = make(map[string]Level, len(parsed))
+	}
+	for pattern, level := range parsed {
+		r.levels[pattern] = level
+	}
+	return nil
+}
+
+// String returns the min levels in the same format that Parse accepts, sorted by pattern.
+func (r *LevelRegistry) String() string {
+	levels := r.Levels()
+	patterns := make([]string, 0, len(levels))
+	for pattern := range levels {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for i, pattern := range patterns {
+		patterns[i] = pattern + "=" + levels[pattern].String()
+	}
+	return strings.Join(patterns, ",")
+}
+
+// Match returns the min level of the most specific pattern that matches the passed in name, or
+// false if no pattern matches.
+func (r *LevelRegistry) Match(name string) (Level, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	best, bestScore := Level(0), -1
+	for pattern, level := range r.levels {
+		if score := namePatternScore(pattern, name); score > bestScore {
+			best, bestScore = level, score
+		}
+	}
+	return best, bestScore >= 0
+}
+
+// minLevel is called by root Loggers to find the min level for a line logged by the passed in
+// named Logger (if any), falling back to the root's own min level.
+func (r *LevelRegistry) minLevel(name string, rootMin Level) Level {
+	if len(name) == 0 {
+		return rootMin
+	}
+	if level, ok := r.Match(name); ok {
+		return level
+	}
+	return rootMin
+}
+
+// namePatternScore returns how specific the pattern is, if it matches the passed in name, or -1
+// if it doesn't match.
+func namePatternScore(pattern, name string) int {
+	if pattern == "*" {
+		return 0
+	}
+	if prefix := strings.TrimSuffix(pattern, ".*"); len(prefix) < len(pattern) {
+		if name == prefix || strings.HasPrefix(name, prefix+".") {
+			return 2 * len(prefix)
+		}
+		return -1
+	}
+	if name == pattern {
+		return 2*len(pattern) + 1
+	}
+	return -1
+}
+
+func validateNamePattern(pattern string) error {
+	if len(pattern) == 0 {
+		return fmt.Errorf("frog: empty name pattern")
+	}
+	if strings.Contains(strings.TrimSuffix(pattern, ".*"), "*") && pattern != "*" {
+		return fmt.Errorf("frog: invalid name pattern %q (\"*\" is only allowed on its own, or at the end after a \".\")", pattern)
+	}
+	return nil
+}
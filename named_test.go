@@ -0,0 +1,222 @@
+package frog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_NamedLoggerNames(t *testing.T) {
+	var root Logger = &NullLogger{}
+	db := Named(root, "db")
+	pool := Named(WithFields(db, Int("n", 1)), "pool")
+	same := Named(pool, "")
+
+	cases := []struct {
+		Log      Logger
+		Expected string
+	}{
+		{db, "db"},
+		{pool, "db.pool"},
+		{same, "db.pool"},
+	}
+	for _, tc := range cases {
+		if actual := tc.Log.(*NamedLogger).Name(); actual != tc.Expected {
+			t.Errorf("expected %q, got %q", tc.Expected, actual)
+		}
+	}
+}
+
+func Test_NamedLoggerOutput(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewUnbuffered(&buf, &TextPrinter{printLevel: true})
+	pool := Named(WithFields(Named(log, "db"), Int("n", 1)), "pool")
+	pool.Info("connected", String("host", "localhost"))
+	Named(log, "http").Warning("")
+	log.Info("unnamed")
+	if expected := "[nfo] db.pool: connected       n=1 host=localhost\n[WRN] http: \n[nfo] unnamed\n"; buf.String() != expected {
+		t.Errorf("text:\nexpected: %q\nactual:   %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	log = NewUnbuffered(&buf, &TextPrinter{printLevel: true, printMessageLast: true})
+	Named(log, "db").Info("connected", Int("n", 1))
+	if expected := "[nfo] n=1       db: connected\n"; buf.String() != expected {
+		t.Errorf("text (message last):\nexpected: %q\nactual:   %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	log = NewUnbuffered(&buf, newTestJSONPrinter())
+	pool = Named(WithFields(Named(log, "db"), Int("n", 1)), "pool")
+	pool.Info("connected")
+	expected := testJSONLine("info", "connected", `"logger":"db.pool","n":1`) + "\n"
+	if buf.String() != expected {
+		t.Errorf("json:\nexpected: %s\nactual:   %s", expected, buf.String())
+	}
+}
+
+func Test_LoggerFieldFromUser(t *testing.T) {
+	// only the name of a named Logger is rendered as a prefix, not just any field named "logger"
+	testFieldOutput(t, String(LoggerField, "mine"), `logger=mine`, `"logger":"mine"`)
+
+	var buf bytes.Buffer
+	log := NewUnbuffered(&buf, &TextPrinter{})
+	Named(log, "db").Info("connected", String(LoggerField, "mine"))
+	if expected := "db: connected       logger=mine\n"; buf.String() != expected {
+		t.Errorf("\nexpected: %q\nactual:   %q", expected, buf.String())
+	}
+}
+
+func Test_LoggerFieldFromUserWithDuplicates(t *testing.T) {
+	cases := []struct {
+		Policy       DuplicatePolicy
+		ExpectedText string
+		ExpectedJSON string
+	}{
+		{DuplicatesLastWins, "db: connected       logger=theirs\n", `"logger":"db","logger_2":"theirs"`},
+		{DuplicatesFirstWins, "db: connected       logger=mine\n", `"logger":"db","logger_2":"mine"`},
+		{DuplicatesSuffix, "db: connected       logger=mine logger_2=theirs\n", `"logger":"db","logger_2":"mine","logger_3":"theirs"`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Policy.String(), func(t *testing.T) {
+			logLine := func(log Logger) {
+				WithFields(Named(log, "db"), String(LoggerField, "mine")).Info("connected", String(LoggerField, "theirs"))
+			}
+			opt := PODuplicateFields(tc.Policy)
+
+			var buf bytes.Buffer
+			logLine(NewUnbuffered(&buf, (&TextPrinter{}).SetOptions(opt)))
+			if buf.String() != tc.ExpectedText {
+				t.Errorf("text:\nexpected: %q\nactual:   %q", tc.ExpectedText, buf.String())
+			}
+
+			buf.Reset()
+			prn, err := NewTemplatePrinter("{logger}: {msg} {fields}")
+			if err != nil {
+				t.Fatal(err)
+			}
+			logLine(NewUnbuffered(&buf, prn.SetOptions(opt)))
+			if expected := "db: connected " + strings.TrimSpace(tc.ExpectedText[len("db: connected"):]) + "\n"; buf.String() != expected {
+				t.Errorf("template:\nexpected: %q\nactual:   %q", expected, buf.String())
+			}
+
+			buf.Reset()
+			logLine(NewUnbuffered(&buf, newTestJSONPrinter(opt)))
+			if expected := testJSONLine("info", "connected", tc.ExpectedJSON) + "\n"; buf.String() != expected {
+				t.Errorf("json:\nexpected: %s\nactual:   %s", expected, buf.String())
+			}
+		})
+	}
+}
+
+func Test_NamedLevels(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewUnbuffered(&buf, &TextPrinter{printLevel: false})
+	db := Named(log, "db")
+	pool := Named(db, "pool")
+	conn := Named(pool, "conn")
+	http := Named(log, "http")
+
+	logAll := func() {
+		for _, l := range []Logger{log, db, pool, conn, http} {
+			l.Verbose("verbose")
+			l.Info("info")
+			l.Warning("warning")
+		}
+	}
+	check := func(expected ...string) {
+		t.Helper()
+		actual := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if strings.Join(actual, "|") != strings.Join(expected, "|") {
+			t.Errorf("\nexpected: %q\nactual:   %q", expected, actual)
+		}
+		buf.Reset()
+	}
+
+	registry := NamedLevels(http)
+	if registry == nil || registry != NamedLevels(log) {
+		t.Fatalf("expected named loggers to share the root's registry")
+	}
+
+	if err := registry.Parse("db.*=verbose; db.pool=warning, http = error"); err != nil {
+		t.Fatal(err)
+	}
+	logAll()
+	check(
+		"info", "warning",
+		"db: verbose", "db: info", "db: warning",
+		"db.pool: warning",
+		"db.pool.conn: verbose", "db.pool.conn: info", "db.pool.conn: warning",
+	)
+
+	// changes apply to existing loggers
+	registry.ClearLevel("db.pool")
+	if err := registry.SetLevel("*", Warning); err != nil {
+		t.Fatal(err)
+	}
+	logAll()
+	check(
+		"info", "warning",
+		"db: verbose", "db: info", "db: warning",
+		"db.pool: verbose", "db.pool: info", "db.pool: warning",
+		"db.pool.conn: verbose", "db.pool.conn: info", "db.pool.conn: warning",
+	)
+
+	if expected := "*=warning,db.*=verbose,http=error"; registry.String() != expected {
+		t.Errorf("expected %q, got %q", expected, registry.String())
+	}
+
+	// a child's own min level still applies
+	pool.SetMinLevel(Warning)
+	registry.Reset()
+	logAll()
+	pool.SetMinLevel(Transient)
+	check("info", "warning", "db: info", "db: warning", "db.pool: warning", "db.pool.conn: warning", "http: info", "http: warning")
+}
+
+func Test_NamedLevelsMatch(t *testing.T) {
+	var r LevelRegistry
+	if _, ok := r.Match("db"); ok {
+		t.Errorf("expected no match from an empty registry")
+	}
+	if err := r.Parse("*=error, db.*=warning, db=info, db.pool.*=verbose, db.pool.conn=trace"); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		Name     string
+		Expected Level
+	}{
+		{"http", Error},
+		{"dbx", Error},
+		{"db", Info},
+		{"db.other", Warning},
+		{"db.pool", Verbose},
+		{"db.pool.idle", Verbose},
+		{"db.pool.conn", Trace},
+		{"db.pool.conn.x", Verbose},
+	}
+	for _, tc := range cases {
+		if actual, ok := r.Match(tc.Name); !ok || actual != tc.Expected {
+			t.Errorf("%s: expected %v, got %v (%v)", tc.Name, tc.Expected, actual, ok)
+		}
+	}
+}
+
+func Test_NamedLevelsParseErrors(t *testing.T) {
+	var r LevelRegistry
+	for _, spec := range []string{"db", "db=loud", "=info", "db*=info", "*.db=info", "db.*.x=info"} {
+		if err := r.Parse(spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+	if err := r.Parse("db=info, http=loud"); err == nil {
+		t.Errorf("expected an error")
+	}
+	if len(r.Levels()) != 0 {
+		t.Errorf("expected an invalid spec to not change any levels, got %v", r.Levels())
+	}
+
+	if NamedLevels(&NullLogger{}) != nil {
+		t.Errorf("expected no registry without a root logger that has one")
+	}
+}
@@ -0,0 +1,116 @@
+package frog
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// IP adds a field for an IPv4 or IPv6 address
+func IP(name string, value net.IP) FieldIP {
+	return FieldIP{Name: name, Value: value}
+}
+
+// MAC adds a field for a hardware address (e.g. "00:00:5e:00:53:01")
+func MAC(name string, value net.HardwareAddr) FieldMAC {
+	return FieldMAC{Name: name, Value: value}
+}
+
+// URL adds a field for a URL. To avoid leaking credentials, see the StripUserinfo and
+// RedactQuery methods, e.g.:
+//
+//	frog.URL("url", u).StripUserinfo().RedactQuery("token", "key")
+func URL(name string, value *url.URL) FieldURL {
+	return FieldURL{Name: name, Value: value}
+}
+
+// IP
+
+type FieldIP struct {
+	Name  string
+	Value net.IP
+}
+
+func (f FieldIP) Field() Field {
+	if f.Value == nil {
+		return Field{Name: f.Name, Value: "null"}
+	}
+	return Field{Name: f.Name, Value: f.Value.String(), IsJSONString: true, IsJSONSafe: true}
+}
+
+// MAC
+
+type FieldMAC struct {
+	Name  string
+	Value net.HardwareAddr
+}
+
+func (f FieldMAC) Field() Field {
+	if f.Value == nil {
+		return Field{Name: f.Name, Value: "null"}
+	}
+	return Field{Name: f.Name, Value: f.Value.String(), IsJSONString: true, IsJSONSafe: true}
+}
+
+// URL
+
+type FieldURL struct {
+	Name  string
+	Value *url.URL
+
+	// StripUser removes any username and password from the URL
+	StripUser bool
+	// RedactParams lists the names of query parameters whose values are replaced with RedactedText
+	RedactParams []string
+}
+
+// StripUserinfo returns a copy of the field that removes any username and password from the URL
+// before it is rendered.
+func (f FieldURL) StripUserinfo() FieldURL {
+	f.StripUser = true
+	return f
+}
+
+// RedactQuery returns a copy of the field that replaces the values of any query parameters with
+// the passed in names with RedactedText before it is rendered.
+func (f FieldURL) RedactQuery(params ...string) FieldURL {
+	f.RedactParams = append(f.RedactParams[:len(f.RedactParams):len(f.RedactParams)], params...)
+	return f
+}
+
+func (f FieldURL) Field() Field {
+	if f.Value == nil {
+		return Field{Name: f.Name, Value: "null"}
+	}
+	u := *f.Value // shallow copy, so we can modify it without touching the caller's URL
+	if f.StripUser {
+		u.User = nil
+	}
+	if len(f.RedactParams) > 0 && len(u.RawQuery) > 0 {
+		u.RawQuery = redactRawQuery(u.RawQuery, f.RedactParams)
+	}
+	return Field{Name: f.Name, Value: u.String(), IsJSONString: true}
+}
+
+// redactRawQuery replaces the values of the named parameters, while otherwise leaving the query
+// as-is (url.Values.Encode would sort the parameters and re-escape their values).
+func redactRawQuery(query string, params []string) string {
+	parts := strings.Split(query, "&")
+	for i, part := range parts {
+		rawKey := part
+		if eq := strings.IndexByte(part, '='); eq >= 0 {
+			rawKey = part[:eq]
+		}
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			key = rawKey
+		}
+		for _, param := range params {
+			if key == param {
+				parts[i] = rawKey + "=" + RedactedText
+				break
+			}
+		}
+	}
+	return strings.Join(parts, "&")
+}
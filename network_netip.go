@@ -0,0 +1,44 @@
+//go:build go1.18
+// +build go1.18
+
+package frog
+
+import "net/netip"
+
+// Addr adds a field for a netip.Addr (an IPv4 or IPv6 address)
+func Addr(name string, value netip.Addr) FieldAddr {
+	return FieldAddr{Name: name, Value: value}
+}
+
+// AddrPort adds a field for a netip.AddrPort (an IP address and port, e.g. "[::1]:80")
+func AddrPort(name string, value netip.AddrPort) FieldAddrPort {
+	return FieldAddrPort{Name: name, Value: value}
+}
+
+// Addr
+
+type FieldAddr struct {
+	Name  string
+	Value netip.Addr
+}
+
+func (f FieldAddr) Field() Field {
+	if !f.Value.IsValid() {
+		return Field{Name: f.Name, Value: "null"}
+	}
+	return Field{Name: f.Name, Value: f.Value.String(), IsJSONString: true}
+}
+
+// AddrPort
+
+type FieldAddrPort struct {
+	Name  string
+	Value netip.AddrPort
+}
+
+func (f FieldAddrPort) Field() Field {
+	if !f.Value.IsValid() {
+		return Field{Name: f.Name, Value: "null"}
+	}
+	return Field{Name: f.Name, Value: f.Value.String(), IsJSONString: true}
+}
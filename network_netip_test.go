@@ -0,0 +1,30 @@
+//go:build go1.18
+// +build go1.18
+
+package frog
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func Test_NetIPFields(t *testing.T) {
+	cases := []struct {
+		Name         string
+		Fielder      Fielder
+		ExpectedText string
+		ExpectedJSON string
+	}{
+		{"addr", Addr("ip", netip.MustParseAddr("192.0.2.1")), `ip=192.0.2.1`, `"ip":"192.0.2.1"`},
+		{"zoned addr", Addr("ip", netip.MustParseAddr("fe80::1%eth0")), `ip=fe80::1%eth0`, `"ip":"fe80::1%eth0"`},
+		{"zero addr", Addr("ip", netip.Addr{}), `ip=null`, `"ip":null`},
+		{"addrport", AddrPort("ap", netip.MustParseAddrPort("[::1]:8080")), `ap=[::1]:8080`, `"ap":"[::1]:8080"`},
+		{"zero addrport", AddrPort("ap", netip.AddrPort{}), `ap=null`, `"ap":null`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			testFieldOutput(t, tc.Fielder, tc.ExpectedText, tc.ExpectedJSON)
+		})
+	}
+}
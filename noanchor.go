@@ -6,7 +6,7 @@ package frog
 // does nothing but pass through to the parent.
 type NoAnchorLogger struct {
 	parent   Logger
-	minLevel Level // defaults to Transient
+	minLevel atomicLevel // defaults to Transient
 }
 
 func newNoAnchor(parent Logger) *NoAnchorLogger {
@@ -22,16 +22,16 @@ func (l *NoAnchorLogger) Parent() Logger {
 }
 
 func (l *NoAnchorLogger) MinLevel() Level {
-	return l.minLevel
+	return l.minLevel.Load()
 }
 
 func (l *NoAnchorLogger) SetMinLevel(level Level) Logger {
-	l.minLevel = level
+	l.minLevel.Store(level)
 	return l
 }
 
 func (l *NoAnchorLogger) LogImpl(level Level, msg string, fielders []Fielder, opts []PrinterOption, d ImplData) {
-	d.MergeMinLevel(l.minLevel)
+	d.MergeMinLevel(l.minLevel.Load())
 	l.parent.LogImpl(level, msg, fielders, opts, d)
 }
 
@@ -40,6 +40,11 @@ func (l *NoAnchorLogger) Transient(msg string, fielders ...Fielder) Logger {
 	return l
 }
 
+func (l *NoAnchorLogger) Trace(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Trace, msg, fielders, nil, ImplData{})
+	return l
+}
+
 func (l *NoAnchorLogger) Verbose(msg string, fielders ...Fielder) Logger {
 	l.LogImpl(Verbose, msg, fielders, nil, ImplData{})
 	return l
@@ -60,7 +65,16 @@ func (l *NoAnchorLogger) Error(msg string, fielders ...Fielder) Logger {
 	return l
 }
 
+func (l *NoAnchorLogger) Fatal(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Fatal, msg, fielders, nil, ImplData{})
+	fatalExit(l)
+	return l
+}
+
 func (l *NoAnchorLogger) Log(level Level, msg string, fielders ...Fielder) Logger {
 	l.LogImpl(level, msg, fielders, nil, ImplData{})
+	if level == Fatal {
+		fatalExit(l)
+	}
 	return l
 }
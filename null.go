@@ -1,18 +1,18 @@
 package frog
 
 type NullLogger struct {
-	minLevel Level
+	minLevel atomicLevel
 }
 
 func (n *NullLogger) Close() {
 }
 
 func (n *NullLogger) MinLevel() Level {
-	return n.minLevel
+	return n.minLevel.Load()
 }
 
 func (n *NullLogger) SetMinLevel(level Level) Logger {
-	n.minLevel = level
+	n.minLevel.Store(level)
 	return n
 }
 
@@ -23,6 +23,10 @@ func (n *NullLogger) Transient(format string, fielders ...Fielder) Logger {
 	return n
 }
 
+func (n *NullLogger) Trace(format string, fielders ...Fielder) Logger {
+	return n
+}
+
 func (n *NullLogger) Verbose(format string, fielders ...Fielder) Logger {
 	return n
 }
@@ -39,6 +43,16 @@ func (n *NullLogger) Error(format string, fielders ...Fielder) Logger {
 	return n
 }
 
+// Fatal doesn't log anything, but still exits, as the caller is not expecting to continue.
+func (n *NullLogger) Fatal(format string, fielders ...Fielder) Logger {
+	fatalExit(n)
+	return n
+}
+
+// Log doesn't log anything, but still exits if the level is Fatal (see Fatal).
 func (n *NullLogger) Log(level Level, msg string, fielders ...Fielder) Logger {
+	if level == Fatal {
+		fatalExit(n)
+	}
 	return n
 }
@@ -2,56 +2,92 @@ package frog
 
 import "github.com/danbrakeley/ansi"
 
-// Color is the public interface to the underlying ANSI colors.
-type Color byte
-
-const (
-	Black Color = iota
-	DarkRed
-	DarkGreen
-	DarkYellow
-	DarkBlue
-	DarkMagenta
-	DarkCyan
-	LightGray
-	DarkGray
-	Red
-	Green
-	Yellow
-	Blue
-	Magenta
-	Cyan
-	White
-)
-
 // Palette is the primary (0) and secondary (1) colors for each log level
 type Palette [levelMax][2]Color
 
 var DefaultPalette = Palette{
-	{DarkGreen, DarkGray}, // Transient
-	{Cyan, DarkCyan},      // Verbose
-	{White, LightGray},    // Info
-	{Yellow, DarkYellow},  // Warning
-	{Red, DarkRed},        // Error
+	Transient: {DarkGreen, DarkGray},
+	Trace:     {DarkCyan, DarkGray},
+	Verbose:   {Cyan, DarkCyan},
+	Info:      {White, LightGray},
+	Warning:   {Yellow, DarkYellow},
+	Error:     {Red, DarkRed},
+	Fatal:     {Magenta, DarkMagenta},
 }
 
 var DarkPalette = Palette{
-	{DarkGray, DarkGray}, // Transient
-	{DarkGray, DarkGray}, // Verbose
-	{DarkGray, DarkGray}, // Info
-	{DarkGray, DarkGray}, // Warning
-	{DarkGray, DarkGray}, // Error
+	Transient: {DarkGray, DarkGray},
+	Trace:     {DarkGray, DarkGray},
+	Verbose:   {DarkGray, DarkGray},
+	Info:      {DarkGray, DarkGray},
+	Warning:   {DarkGray, DarkGray},
+	Error:     {DarkGray, DarkGray},
+	Fatal:     {DarkGray, DarkGray},
+}
+
+// ValuePalette colors field values by their kind (see FieldKind), and can highlight the names of
+// specific fields. Kinds or names that are not in the maps use the level's colors, as usual.
+type ValuePalette struct {
+	Kinds map[FieldKind]Color
+	Names map[string]Color
+}
+
+var DefaultValuePalette = ValuePalette{
+	Kinds: map[FieldKind]Color{
+		KindNumber:   Cyan,
+		KindBool:     Blue,
+		KindDuration: Magenta,
+		KindError:    Red,
+		KindNull:     DarkGray,
+	},
+}
+
+// valueColors is the internal version of a ValuePalette, with ANSI escape sequences for each color
+
+type valueColors struct {
+	kinds  [kindMax]string
+	names  map[string]string
+	styled bool // true if any colors have backgrounds or styles, which must be reset afterwards
+}
+
+func (p *ValuePalette) toANSI() *valueColors {
+	depth := DetectColorDepth()
+	out := valueColors{names: make(map[string]string, len(p.Names))}
+	for kind, c := range p.Kinds {
+		if kind < kindMax {
+			out.kinds[kind] = c.ansi(depth, false)
+			out.styled = out.styled || !c.isPlain()
+		}
+	}
+	for name, c := range p.Names {
+		out.names[name] = c.ansi(depth, false)
+		out.styled = out.styled || !c.isPlain()
+	}
+	return &out
 }
 
 // internally, a palette is an ANSI escape sequence (string) for each pair of colors at each log level
 
 type ansicolors [levelMax][2]string
 
+// toANSI converts the palette to escape sequences, downgrading colors to fit the color depth of the
+// terminal (see DetectColorDepth).
 func (p *Palette) toANSI() ansicolors {
+	return p.toANSIDepth(DetectColorDepth())
+}
+
+func (p *Palette) toANSIDepth(depth ColorDepth) ansicolors {
+	// if any colors have backgrounds or styles, then every color needs to reset them, so that they
+	// don't bleed from one color to the next
+	reset := false
+	for i := levelMin; i < levelMax; i++ {
+		reset = reset || !p[i][0].isPlain() || !p[i][1].isPlain()
+	}
+
 	var out ansicolors
 	for i := levelMin; i < levelMax; i++ {
-		out[i][0] = ansiFgColor(p[i][0])
-		out[i][1] = ansiFgColor(p[i][1])
+		out[i][0] = p[i][0].ansi(depth, reset)
+		out[i][1] = p[i][1].ansi(depth, reset)
 	}
 	return out
 }
@@ -2,7 +2,6 @@ package frog
 
 import (
 	"fmt"
-	"os"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -10,20 +9,22 @@ import (
 	"github.com/danbrakeley/ansi"
 )
 
-var hasEnvVarNoColor = false
-
-func init() {
-	_, exists := os.LookupEnv("NO_COLOR")
-	hasEnvVarNoColor = exists
-}
-
 type Printer interface {
 	Render(Level, []PrinterOption, string, []Field) string
 	SetOptions(...PrinterOption) Printer
 }
 
 type TextPrinter struct {
-	palette    ansicolors
+	palette ansicolors
+	// valueColors, if set, colors field values by kind, and highlights specific field names.
+	valueColors *valueColors
+	// labels override the default label for each level (if not empty).
+	labels LevelLabels
+	// colorMode is set via POColor, and takes precedence over envColorMode.
+	colorMode ColorMode
+	// envColorMode is the color mode requested by the environment when the root logger was created.
+	envColorMode ColorMode
+
 	printTime  bool
 	printLevel bool
 
@@ -51,6 +52,43 @@ type TextPrinter struct {
 	// A value of 0 means no cropping will occur. If an anchored line ends up being wider than the terminal,
 	// it will wrap, which will throw off the formatting and scramble the output.
 	transientLineLength int
+
+	// timeLayout is the layout used to format the time (if empty, textTimeLayout is used).
+	timeLayout string
+	// timeUTC converts the time to UTC before formatting it.
+	timeUTC bool
+	// timePrecision controls how many fractional seconds are displayed (e.g. time.Millisecond).
+	// Fractional seconds are appended to the end of the time layout.
+	timePrecision time.Duration
+	// timeElapsed displays the time elapsed since the logger started, instead of the current time.
+	timeElapsed bool
+	// start is when the root logger was created, and is used to calculate elapsed time.
+	start time.Time
+	// clock is the source of the current time (if nil, SystemClock is used).
+	clock Clock
+
+	// printCaller appends the file and line of the code that logged the line.
+	printCaller bool
+	// callerPath controls how much of the caller's file path is displayed.
+	callerPath CallerPath
+	// callerSkip is the number of stack frames to skip beyond frog's own frames.
+	callerSkip int
+
+	// printStack adds a stack trace below each Error line.
+	printStack bool
+
+	// duplicates controls how fields with the same name are handled.
+	duplicates DuplicatePolicy
+
+	// maxFieldLength is the max length (in bytes) of each field value (0 means no limit).
+	maxFieldLength int
+	// maxLineLength is the max length (in bytes) of each rendered line (0 means no limit).
+	maxLineLength int
+}
+
+func (p *TextPrinter) copyPrinter() Printer {
+	cp := *p
+	return &cp
 }
 
 func (p *TextPrinter) SetOptions(opts ...PrinterOption) Printer {
@@ -58,6 +96,13 @@ func (p *TextPrinter) SetOptions(opts ...PrinterOption) Printer {
 		switch ot := o.(type) {
 		case poPalette:
 			p.palette = ot.ANSIColors
+		case poValuePalette:
+			p.valueColors = ot.ValueColors
+		case poTheme:
+			p.palette = ot.ANSIColors
+			p.labels.set(ot.Labels)
+		case poLevelLabels:
+			p.labels.set(ot.Labels)
 		case poTime:
 			p.printTime = ot.Visible
 		case poLevel:
@@ -70,6 +115,36 @@ func (p *TextPrinter) SetOptions(opts ...PrinterOption) Printer {
 			p.printMessageLast = true
 		case poTransientLineLength:
 			p.transientLineLength = ot.Cols
+		case poTimeFormat:
+			p.timeLayout = ot.Layout
+		case poTimeUTC:
+			p.timeUTC = ot.UTC
+		case poTimePrecision:
+			p.timePrecision = ot.Precision
+		case poTimeElapsed:
+			p.timeElapsed = ot.Elapsed
+		case poClock:
+			p.clock = ot.Clock
+		case poLoggerStart:
+			p.start = p.currentTime()
+		case poColor:
+			p.colorMode = ot.Mode
+		case poColorEnv:
+			p.envColorMode = ot.Mode
+		case poCaller:
+			p.printCaller = ot.Visible
+		case poCallerPath:
+			p.callerPath = ot.Style
+		case poCallerSkip:
+			p.callerSkip = ot.Frames
+		case poStackTrace:
+			p.printStack = ot.Visible
+		case poDuplicateFields:
+			p.duplicates = ot.Policy
+		case poMaxFieldLength:
+			p.maxFieldLength = ot.Length
+		case poMaxLineLength:
+			p.maxLineLength = ot.Length
 		}
 	}
 	return p
@@ -94,16 +169,23 @@ func (p *TextPrinter) Render(level Level, opts []PrinterOption, msg string, fiel
 		return tmp.Render(level, nil, msg, fields)
 	}
 
-	var useColor bool
-	var colorPrimary, colorSecondary string
+	fields = resolveDuplicates(fields, p.duplicates)
 
-	if !hasEnvVarNoColor {
-		colorPrimary = p.palette[level][0]
-		colorSecondary = p.palette[level][1]
-		useColor = len(colorPrimary) > 0 && len(colorSecondary) > 0
+	if p.maxFieldLength > 0 || p.maxLineLength > 0 {
+		tmp := *p
+		tmp.clock = fixedClock(p.currentTime())
+		return renderWithLimits(p.maxLineLength, p.maxFieldLength, msg, fields, func(msg string, fields []Field) string {
+			return tmp.render(level, msg, fields)
+		})
 	}
+	return p.render(level, msg, fields)
+}
+
+func (p *TextPrinter) render(level Level, msg string, fields []Field) string {
+	useColor, colorPrimary, colorSecondary := p.colors(level)
 
 	msg = escapeMessageForTerminal(trimNewlines(msg))
+	name, fields := splitLoggerField(fields)
 
 	var sb strings.Builder
 	sb.Grow(256)
@@ -113,65 +195,34 @@ func (p *TextPrinter) Render(level Level, opts []PrinterOption, msg string, fiel
 	}
 
 	if p.printTime {
-		sb.WriteString(fmt.Sprintf("%s ", time.Now().Format("2006.01.02-15:04:05")))
+		sb.WriteString(p.timestamp())
+		sb.WriteByte(' ')
 	}
 
 	if p.printLevel {
-		switch level {
-		case Transient:
-			sb.WriteString("[==>] ")
-		case Verbose:
-			sb.WriteString("[dbg] ")
-		case Info:
-			sb.WriteString("[nfo] ")
-		case Warning:
-			sb.WriteString("[WRN] ")
-		case Error:
-			sb.WriteString("[ERR] ")
-		default:
-			sb.WriteString("[???] ")
-		}
+		sb.WriteString(p.levelLabel(level))
+		sb.WriteByte(' ')
 	}
 
 	fnWriteMsg := func() int {
+		var n int
+		if len(name) > 0 {
+			if useColor {
+				sb.WriteString(colorSecondary)
+			}
+			sb.WriteString(name)
+			sb.WriteString(": ")
+			n = utf8.RuneCountInString(name) + 2
+		}
 		if useColor {
 			sb.WriteString(colorPrimary)
 		}
 		sb.WriteString(msg)
-		return utf8.RuneCountInString(msg)
+		return n + utf8.RuneCountInString(msg)
 	}
 
 	fnWriteFields := func() int {
-		count := 0
-		for i, field := range fields {
-			if i != 0 {
-				sb.WriteByte(' ')
-				count++
-			}
-			v := field.Value
-			if field.IsJSONString {
-				if !field.IsJSONSafe {
-					v = escapeStringFieldForTerminal(v)
-				}
-				if len(v) == 0 || strings.ContainsAny(v, " \\") {
-					v = "\"" + v + "\""
-				}
-			}
-
-			if useColor {
-				sb.WriteString(colorSecondary)
-			}
-			sb.WriteString(field.Name)
-			count += utf8.RuneCountInString(field.Name)
-			sb.WriteByte('=')
-			count += 1
-			if useColor {
-				sb.WriteString(colorPrimary)
-			}
-			sb.WriteString(v)
-			count += utf8.RuneCountInString(v)
-		}
-		return count
+		return writeTextFields(&sb, fields, useColor, colorPrimary, colorSecondary, p.valueColors)
 	}
 
 	// write left side
@@ -179,7 +230,7 @@ func (p *TextPrinter) Render(level Level, opts []PrinterOption, msg string, fiel
 	var hasRightSide bool
 	if p.printMessageLast {
 		visibleRuneCount = fnWriteFields()
-		hasRightSide = len(msg) > 0
+		hasRightSide = len(msg) > 0 || len(name) > 0
 	} else {
 		visibleRuneCount = fnWriteMsg()
 		hasRightSide = len(fields) > 0
@@ -211,73 +262,402 @@ func (p *TextPrinter) Render(level Level, opts []PrinterOption, msg string, fiel
 		}
 	}
 
+	if p.printCaller {
+		if c, ok := captureCaller(p.callerSkip, p.callerPath); ok {
+			if useColor {
+				sb.WriteString(colorSecondary)
+				sb.WriteString(ansiDim)
+			}
+			sb.WriteString("   ")
+			sb.WriteString(c.FileLine())
+		}
+	}
+
+	p.writeStack(&sb, level, useColor, colorSecondary)
+
 	if useColor {
 		sb.WriteString(ansi.Reset)
 	}
 
-	out := sb.String()
+	return p.cropTransient(level, sb.String())
+}
+
+// splitLoggerField returns the name of the named Logger (if any), along with the rest of the
+// fields. Other fields that happen to be named "logger" are left alone.
+func splitLoggerField(fields []Field) (string, []Field) {
+	for i, f := range fields {
+		if !f.isLoggerName {
+			continue
+		}
+		rest := make([]Field, 0, len(fields)-1)
+		rest = append(rest, fields[:i]...)
+		rest = append(rest, fields[i+1:]...)
+		return escapeMessageForTerminal(f.Value), rest
+	}
+	return "", fields
+}
+
+// writeStack writes an indented stack trace on the lines following an Error line, if stack
+// traces are enabled.
+func (p *TextPrinter) writeStack(sb *strings.Builder, level Level, useColor bool, colorSecondary string) {
+	if !p.printStack || level < Error {
+		return
+	}
+	if useColor {
+		sb.WriteString(colorSecondary)
+		sb.WriteString(ansiDim)
+	}
+	for _, line := range captureStack(p.callerSkip) {
+		sb.WriteString("\n    ")
+		sb.WriteString(line)
+	}
+}
+
+// ansiDim is the SGR sequence for dim/faint text
+const ansiDim = ansi.CSI + "2m"
+
+// colors returns the primary and secondary ANSI color sequences to use for the given level, and
+// whether or not colors should be used at all.
+func (p *TextPrinter) colors(level Level) (useColor bool, primary, secondary string) {
+	palette := &p.palette
+	switch p.colorMode {
+	case ColorNever:
+		return false, "", ""
+	case ColorAlways:
+		// only an explicit POColor(ColorAlways) can add colors to a printer without a palette
+		if len(palette[level][0]) == 0 || len(palette[level][1]) == 0 {
+			palette = &defaultANSIColors
+		}
+	default:
+		// the environment can turn colors off, but a palette is still needed to turn them on
+		if p.envColorMode == ColorNever {
+			return false, "", ""
+		}
+	}
+	primary = palette[level][0]
+	secondary = palette[level][1]
+	return len(primary) > 0 && len(secondary) > 0, primary, secondary
+}
+
+// textTimeLayout is the default layout used when displaying the time.
+const textTimeLayout = "2006.01.02-15:04:05"
+
+// timestamp returns the current time (or elapsed time), formatted for display.
+func (p *TextPrinter) timestamp() string {
+	return p.timestampLayout("")
+}
+
+// timestampLayout returns the current time, formatted with the given layout. If the given layout
+// is empty, then the printer's time layout and precision are used instead.
+func (p *TextPrinter) timestampLayout(layout string) string {
+	now := p.currentTime()
+
+	if p.timeElapsed {
+		var elapsed time.Duration
+		if !p.start.IsZero() {
+			elapsed = now.Sub(p.start)
+		}
+		return formatElapsed(elapsed, p.timePrecision)
+	}
+
+	if p.timeUTC {
+		now = now.UTC()
+	}
+	if len(layout) == 0 {
+		layout = p.timeLayout
+		if len(layout) == 0 {
+			layout = textTimeLayout
+		}
+		layout += fractionLayout(p.timePrecision)
+	}
+	return now.Format(layout)
+}
+
+func (p *TextPrinter) currentTime() time.Time {
+	if p.clock != nil {
+		return p.clock.Now()
+	}
+	return SystemClock.Now()
+}
+
+// fractionLayout returns the time layout needed to display fractional seconds with the given
+// precision (rounding down to the nearest millisecond, microsecond, or nanosecond).
+func fractionLayout(precision time.Duration) string {
+	switch {
+	case precision <= 0 || precision >= time.Second:
+		return ""
+	case precision >= time.Millisecond:
+		return ".000"
+	case precision >= time.Microsecond:
+		return ".000000"
+	}
+	return ".000000000"
+}
 
+// formatElapsed formats a duration as hours, minutes, and seconds (e.g. "01:02:03"), followed by
+// fractional seconds, as specified by the precision.
+func formatElapsed(d time.Duration, precision time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	h := d / time.Hour
+	m := (d % time.Hour) / time.Minute
+	sec := (d % time.Minute) / time.Second
+	out := fmt.Sprintf("%s%02d:%02d:%02d", sign, h, m, sec)
+
+	frac := d % time.Second
+	switch fractionLayout(precision) {
+	case ".000":
+		out += fmt.Sprintf(".%03d", frac/time.Millisecond)
+	case ".000000":
+		out += fmt.Sprintf(".%06d", frac/time.Microsecond)
+	case ".000000000":
+		out += fmt.Sprintf(".%09d", frac)
+	}
+	return out
+}
+
+// levelLabel returns the label used to display the given level (e.g. "[nfo]").
+func (p *TextPrinter) levelLabel(level Level) string {
+	return p.labels.label(level, defaultLevelLabels.label(level, "[???]"))
+}
+
+// cropTransient crops Transient lines to the transientLineLength (if set), so that anchored lines
+// don't wrap.
+func (p *TextPrinter) cropTransient(level Level, out string) string {
 	if level == Transient && p.transientLineLength > 0 {
 		runeCount := len([]rune(out))
 		if runeCount > p.transientLineLength {
 			out = ansi.CropPreservingANSI(out, p.transientLineLength)
 		}
 	}
-
 	return out
 }
 
+// textFieldValue returns the field's value as it should be displayed by a text-based Printer,
+// escaping and quoting strings as needed.
+func textFieldValue(field Field) string {
+	if len(field.TextValue) > 0 {
+		return field.TextValue
+	}
+	v := field.Value
+	if field.IsJSONString {
+		if !field.IsJSONSafe {
+			v = escapeStringFieldForTerminal(v)
+		}
+		v = quoteIfNeeded(v)
+	}
+	return v
+}
+
+// QuoteText escapes a string for display by a text-based Printer, and adds quotes if needed.
+// It is meant to help when building a Field's TextValue.
+func QuoteText(s string) string {
+	return quoteIfNeeded(escapeStringFieldForTerminal(s))
+}
+
+func quoteIfNeeded(s string) string {
+	if len(s) == 0 || strings.ContainsAny(s, " \\") {
+		return "\"" + s + "\""
+	}
+	return s
+}
+
+// writeTextFields writes each field as name=value, separated by spaces, and returns the number of
+// visible runes written. If vc is not nil, it overrides the colors of specific names and kinds.
+func writeTextFields(sb *strings.Builder, fields []Field, useColor bool, colorPrimary, colorSecondary string, vc *valueColors) int {
+	count := 0
+	for i, field := range fields {
+		if i != 0 {
+			sb.WriteByte(' ')
+			count++
+		}
+		v := textFieldValue(field)
+
+		if useColor {
+			sb.WriteString(vc.nameColor(field.Name, colorSecondary))
+		}
+		sb.WriteString(field.Name)
+		count += utf8.RuneCountInString(field.Name)
+		sb.WriteByte('=')
+		count += 1
+		if useColor {
+			sb.WriteString(vc.valueColor(field, colorPrimary))
+		}
+		sb.WriteString(v)
+		count += utf8.RuneCountInString(v)
+	}
+	return count
+}
+
+// nameColor returns the highlight color for the named field, or def if there isn't one.
+func (vc *valueColors) nameColor(name, def string) string {
+	if vc != nil {
+		if c, ok := vc.names[name]; ok && len(c) > 0 {
+			return c
+		}
+		if vc.styled {
+			// reset any background or styles from the previous field's value
+			return ansi.Reset + def
+		}
+	}
+	return def
+}
+
+// valueColor returns the color for the field's kind of value, or def if there isn't one.
+func (vc *valueColors) valueColor(field Field, def string) string {
+	if vc != nil {
+		if c := vc.kinds[field.kind()]; len(c) > 0 {
+			return c
+		}
+	}
+	return def
+}
+
 type JSONPrinter struct {
-	TimeOverride time.Time // TODO: only tests use this currently, can we instead support POTime for tests?
+	// TimeOverride, if not zero, is used as the timestamp of every line.
+	// Deprecated: use POClock (or ROClock) with a ManualClock instead.
+	TimeOverride time.Time
+
+	clock Clock // if nil, SystemClock is used
+
+	printCaller bool
+	callerPath  CallerPath
+	callerSkip  int
+	printStack  bool
+	duplicates  DuplicatePolicy
+
+	// labels override the level names (if not empty), with any surrounding spaces trimmed.
+	labels LevelLabels
+
+	maxFieldLength int
+	maxLineLength  int
+}
+
+func (p *JSONPrinter) copyPrinter() Printer {
+	cp := *p
+	return &cp
 }
 
 func (p *JSONPrinter) SetOptions(opts ...PrinterOption) Printer {
-	// JSONPrinter doesn't currently respect any printer options
+	for _, o := range opts {
+		switch ot := o.(type) {
+		case poClock:
+			p.clock = ot.Clock
+		case poCaller:
+			p.printCaller = ot.Visible
+		case poCallerPath:
+			p.callerPath = ot.Style
+		case poCallerSkip:
+			p.callerSkip = ot.Frames
+		case poStackTrace:
+			p.printStack = ot.Visible
+		case poDuplicateFields:
+			p.duplicates = ot.Policy
+		case poLevelLabels:
+			p.labels.set(ot.Labels)
+		case poMaxFieldLength:
+			p.maxFieldLength = ot.Length
+		case poMaxLineLength:
+			p.maxLineLength = ot.Length
+		}
+	}
 	return p
 }
 
 func (p *JSONPrinter) Render(level Level, opts []PrinterOption, msg string, fields []Field) string {
+	if len(opts) > 0 {
+		tmp := *p
+		tmp.SetOptions(opts...)
+		return tmp.Render(level, nil, msg, fields)
+	}
+
+	// Unlike the text-based printers, the name of a named Logger shares the "logger" key with any
+	// user fields of the same name, so those user fields are renamed, rather than dropping the name.
+	switch p.duplicates {
+	case DuplicatesKeepAll:
+	case DuplicatesSuffix:
+		// the name comes first, so if it is treated like a user field, the user fields get the suffixes
+		fields = resolveDuplicates(withoutLoggerName(fields), p.duplicates)
+	default:
+		fields = renameLoggerFieldCollisions(resolveDuplicates(fields, p.duplicates))
+	}
+
 	var stamp time.Time
-	if !p.TimeOverride.IsZero() {
+	switch {
+	case !p.TimeOverride.IsZero():
 		stamp = p.TimeOverride
-	} else {
-		stamp = time.Now()
+	case p.clock != nil:
+		stamp = p.clock.Now()
+	default:
+		stamp = SystemClock.Now()
 	}
 
+	if p.maxFieldLength > 0 || p.maxLineLength > 0 {
+		return renderWithLimits(p.maxLineLength, p.maxFieldLength, msg, fields, func(msg string, fields []Field) string {
+			return p.render(stamp, level, msg, fields)
+		})
+	}
+	return p.render(stamp, level, msg, fields)
+}
+
+func (p *JSONPrinter) render(stamp time.Time, level Level, msg string, fields []Field) string {
 	var sb strings.Builder
 	sb.Grow(70 + len(msg) + len(fields)*50)
 
 	sb.WriteString(`{"timestamp":"`)
 	sb.WriteString(stamp.Format(time.RFC3339))
 	sb.WriteString(`","level":"`)
-	sb.WriteString(level.String())
+	sb.WriteString(escapeStringForJSON(strings.TrimSpace(p.labels.label(level, level.String()))))
 	sb.WriteString(`","msg":"`)
 	sb.WriteString(escapeStringForJSON(trimNewlines(msg)))
 	sb.WriteString(`"`)
 
-	for _, field := range fields {
-		if field.IsJSONString {
-			sb.WriteString(`,"`)
-			sb.WriteString(field.Name)
-			sb.WriteString(`":"`)
-			if field.IsJSONSafe {
-				sb.WriteString(field.Value)
-			} else {
-				sb.WriteString(escapeStringForJSON(field.Value))
-			}
+	if p.printCaller {
+		if c, ok := captureCaller(p.callerSkip, p.callerPath); ok {
+			sb.WriteString(`,"caller":"`)
+			sb.WriteString(escapeStringForJSON(c.FileLine()))
+			sb.WriteString(`","caller_func":"`)
+			sb.WriteString(escapeStringForJSON(c.Function))
 			sb.WriteString(`"`)
-		} else {
-			sb.WriteString(`,"`)
-			sb.WriteString(field.Name)
-			sb.WriteString(`":`)
-			sb.WriteString(field.Value)
 		}
 	}
 
+	if p.printStack && level >= Error {
+		sb.WriteString(`,"stack":"`)
+		sb.WriteString(escapeStringForJSON(strings.Join(captureStack(p.callerSkip), "\n")))
+		sb.WriteString(`"`)
+	}
+
+	for _, field := range fields {
+		sb.WriteByte(',')
+		writeJSONField(&sb, field)
+	}
+
 	sb.WriteString(`}`)
 	return sb.String()
 }
 
+// writeJSONField writes the field as a JSON name/value pair (e.g. `"name":"value"`).
+func writeJSONField(sb *strings.Builder, field Field) {
+	sb.WriteByte('"')
+	sb.WriteString(field.Name)
+	if field.IsJSONString {
+		sb.WriteString(`":"`)
+		if field.IsJSONSafe {
+			sb.WriteString(field.Value)
+		} else {
+			sb.WriteString(escapeStringForJSON(field.Value))
+		}
+		sb.WriteByte('"')
+	} else {
+		sb.WriteString(`":`)
+		sb.WriteString(field.Value)
+	}
+}
+
 func escapeMessageForTerminal(s string) string {
 	sb := strings.Builder{}
 	sb.Grow(len(s) * 2) // worst case
@@ -0,0 +1,105 @@
+package frog
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_TextPrinterTimeOptions(t *testing.T) {
+	var buf bytes.Buffer
+	clock := NewManualClock(time.Date(2023, 4, 21, 3, 49, 13, 123456789, time.FixedZone("PDT", -7*60*60)))
+	clock.SetAutoAdvance(1234567891 * time.Nanosecond)
+	log := NewUnbuffered(&buf, &TextPrinter{printTime: true, printLevel: true, clock: clock})
+	timeOptions(log)
+	log.Close()
+	AssertGolden(t, "time-options.unbuf", buf.Bytes())
+}
+
+func Test_TextPrinterValuePalette(t *testing.T) {
+	var buf bytes.Buffer
+	prn := TextPrinter{palette: DefaultPalette.toANSI()}
+	vp := DefaultValuePalette
+	vp.Names = map[string]Color{"user": Green}
+	log := NewUnbuffered(&buf, prn.SetOptions(POValuePalette(vp)))
+	valueKinds(log)
+
+	tmpl, err := NewTemplatePrinter("{msg} {field:n} {fields}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl.SetOptions(POPalette(DefaultPalette), POValuePalette(vp))
+	valueKinds(NewUnbuffered(&buf, tmpl))
+	AssertGolden(t, "value-palette.unbuf", buf.Bytes())
+}
+
+func Test_FieldKind(t *testing.T) {
+	cases := []struct {
+		Fielder  Fielder
+		Expected FieldKind
+	}{
+		{Int("n", -1), KindNumber},
+		{Float64("f", 1.5), KindNumber},
+		{String("s", "123"), KindString},
+		{Bool("b", true), KindBool},
+		{Duration("d", time.Second), KindDuration},
+		{Err(errors.New("oops")), KindError},
+		{ErrChain(errors.New("oops")), KindError},
+		{Err(nil), KindNull},
+		{JSONValue("j", []int{1}), KindUnknown},
+	}
+	for _, tc := range cases {
+		f := tc.Fielder.Field()
+		if actual := f.kind(); actual != tc.Expected {
+			t.Errorf("%s: expected kind %d, got %d", f.Name, tc.Expected, actual)
+		}
+	}
+}
+
+func Test_FormatElapsed(t *testing.T) {
+	cases := []struct {
+		Duration  time.Duration
+		Precision time.Duration
+		Expected  string
+	}{
+		{0, 0, "00:00:00"},
+		{1500 * time.Millisecond, time.Second, "00:00:01"},
+		{1500 * time.Millisecond, time.Millisecond, "00:00:01.500"},
+		{time.Hour + 2*time.Minute + 3*time.Second + 4*time.Microsecond, time.Microsecond, "01:02:03.000004"},
+		{100*time.Hour + 5, time.Nanosecond, "100:00:00.000000005"},
+		{-1500 * time.Millisecond, time.Millisecond, "-00:00:01.500"},
+		{1999 * time.Millisecond, 10 * time.Millisecond, "00:00:01.999"},
+	}
+	for _, tc := range cases {
+		actual := formatElapsed(tc.Duration, tc.Precision)
+		if actual != tc.Expected {
+			t.Errorf("formatElapsed(%v, %v): expected %q, got %q", tc.Duration, tc.Precision, tc.Expected, actual)
+		}
+	}
+}
+
+func timeOptions(l Logger) {
+	l.Info("default time")
+	WithOptions(l, POTimeUTC(true)).Info("utc")
+	WithOptions(l, POTimePrecision(time.Millisecond)).Info("milliseconds")
+	WithOptions(l, POTimePrecision(time.Microsecond)).Info("microseconds")
+	WithOptions(l, POTimePrecision(time.Nanosecond)).Info("nanoseconds")
+	WithOptions(l, POTimeFormat(time.Kitchen)).Info("custom layout")
+	WithOptions(l, POTimeFormat("15:04:05"), POTimePrecision(time.Millisecond), POTimeUTC(true)).Info("custom layout, utc, and milliseconds")
+
+	elapsed := WithOptions(l, POTimeElapsed(true))
+	elapsed.Info("elapsed")
+	WithOptions(elapsed, POTimePrecision(time.Millisecond)).Info("elapsed with milliseconds")
+	elapsed.Info("elapsed again")
+
+	l.Info("parent is unaffected by options on children")
+}
+
+func valueKinds(l Logger) {
+	l.Info("kinds",
+		Int("n", 42), Float64("f", 1.5), String("s", "hello"), Bool("b", true),
+		Duration("d", time.Second), Err(errors.New("oops")), Err(nil), String("user", "bob"),
+	)
+	l.Warning("kinds", Int("n", 42), Bool("b", false))
+}
@@ -1,5 +1,7 @@
 package frog
 
+import "time"
+
 type PrinterOption interface {
 	isPrinterOption()
 	String() string
@@ -18,6 +20,48 @@ type poPalette struct {
 func (poPalette) isPrinterOption() {}
 func (poPalette) String() string   { return "POPalette" }
 
+// Value Palette (colors field values by kind, and highlights specific field names)
+
+func POValuePalette(p ValuePalette) PrinterOption {
+	return poValuePalette{ValueColors: p.toANSI()}
+}
+
+type poValuePalette struct {
+	ValueColors *valueColors
+}
+
+func (poValuePalette) isPrinterOption() {}
+func (poValuePalette) String() string   { return "POValuePalette" }
+
+// Theme (sets the palette, and any level labels)
+
+func POTheme(t Theme) PrinterOption {
+	return poTheme{ANSIColors: t.Palette.toANSI(), Labels: t.Labels}
+}
+
+type poTheme struct {
+	ANSIColors ansicolors
+	Labels     LevelLabels
+}
+
+func (poTheme) isPrinterOption() {}
+func (poTheme) String() string   { return "POTheme" }
+
+// Level Labels (replaces the labels used for each level, with empty labels left as-is)
+// TextPrinter displays the labels as-is, while JSONPrinter uses them (with surrounding spaces
+// trimmed) as the "level" value, so that both agree. See LevelLabelsDefault for presets.
+
+func POLevelLabels(labels LevelLabels) poLevelLabels {
+	return poLevelLabels{Labels: labels}
+}
+
+type poLevelLabels struct {
+	Labels LevelLabels
+}
+
+func (poLevelLabels) isPrinterOption() {}
+func (poLevelLabels) String() string   { return "POLevelLabels" }
+
 // Time
 
 func POTime(visible bool) poTime {
@@ -31,6 +75,191 @@ type poTime struct {
 func (p poTime) isPrinterOption() {}
 func (p poTime) String() string   { return "POTime" }
 
+// Time Format (layout as used by time.Time's Format method)
+
+func POTimeFormat(layout string) poTimeFormat {
+	return poTimeFormat{Layout: layout}
+}
+
+type poTimeFormat struct {
+	Layout string
+}
+
+func (p poTimeFormat) isPrinterOption() {}
+func (p poTimeFormat) String() string   { return "POTimeFormat" }
+
+// Time UTC (if false, local time is used)
+
+func POTimeUTC(utc bool) poTimeUTC {
+	return poTimeUTC{UTC: utc}
+}
+
+type poTimeUTC struct {
+	UTC bool
+}
+
+func (p poTimeUTC) isPrinterOption() {}
+func (p poTimeUTC) String() string   { return "POTimeUTC" }
+
+// Time Precision (e.g. time.Millisecond to display milliseconds, or time.Second for none)
+
+func POTimePrecision(precision time.Duration) poTimePrecision {
+	return poTimePrecision{Precision: precision}
+}
+
+type poTimePrecision struct {
+	Precision time.Duration
+}
+
+func (p poTimePrecision) isPrinterOption() {}
+func (p poTimePrecision) String() string   { return "POTimePrecision" }
+
+// Time Elapsed (display time since the root logger was created, instead of the current time)
+
+func POTimeElapsed(elapsed bool) poTimeElapsed {
+	return poTimeElapsed{Elapsed: elapsed}
+}
+
+type poTimeElapsed struct {
+	Elapsed bool
+}
+
+func (p poTimeElapsed) isPrinterOption() {}
+func (p poTimeElapsed) String() string   { return "POTimeElapsed" }
+
+// Clock (the source of the current time, for use in tests or other special cases)
+
+func POClock(c Clock) poClock {
+	return poClock{Clock: c}
+}
+
+type poClock struct {
+	Clock Clock
+}
+
+func (p poClock) isPrinterOption() {}
+func (p poClock) String() string   { return "POClock" }
+
+// Caller (display the file and line that a log line came from)
+
+func POCaller(visible bool) poCaller {
+	return poCaller{Visible: visible}
+}
+
+type poCaller struct {
+	Visible bool
+}
+
+func (p poCaller) isPrinterOption() {}
+func (p poCaller) String() string   { return "POCaller" }
+
+// Caller Path (how much of the caller's file path to display)
+
+func POCallerPath(style CallerPath) poCallerPath {
+	return poCallerPath{Style: style}
+}
+
+type poCallerPath struct {
+	Style CallerPath
+}
+
+func (p poCallerPath) isPrinterOption() {}
+func (p poCallerPath) String() string   { return "POCallerPath" }
+
+// Caller Skip (number of extra stack frames to skip, e.g. when logging through a helper function)
+
+func POCallerSkip(frames int) poCallerSkip {
+	return poCallerSkip{Frames: frames}
+}
+
+type poCallerSkip struct {
+	Frames int
+}
+
+func (p poCallerSkip) isPrinterOption() {}
+func (p poCallerSkip) String() string   { return "POCallerSkip" }
+
+// Stack Trace (include a stack trace with each Error line)
+
+func POStackTrace(visible bool) poStackTrace {
+	return poStackTrace{Visible: visible}
+}
+
+type poStackTrace struct {
+	Visible bool
+}
+
+func (p poStackTrace) isPrinterOption() {}
+func (p poStackTrace) String() string   { return "POStackTrace" }
+
+// Duplicate Fields (what to do when multiple fields on a line have the same name)
+
+func PODuplicateFields(policy DuplicatePolicy) poDuplicateFields {
+	return poDuplicateFields{Policy: policy}
+}
+
+type poDuplicateFields struct {
+	Policy DuplicatePolicy
+}
+
+func (p poDuplicateFields) isPrinterOption() {}
+func (p poDuplicateFields) String() string   { return "PODuplicateFields" }
+
+// Max Field Length (field values longer than this many bytes are truncated)
+
+func POMaxFieldLength(length int) poMaxFieldLength {
+	return poMaxFieldLength{Length: length}
+}
+
+type poMaxFieldLength struct {
+	Length int
+}
+
+func (p poMaxFieldLength) isPrinterOption() {}
+func (p poMaxFieldLength) String() string   { return "POMaxFieldLength" }
+
+// Max Line Length (lines longer than this many bytes have their fields and message shortened)
+
+func POMaxLineLength(length int) poMaxLineLength {
+	return poMaxLineLength{Length: length}
+}
+
+type poMaxLineLength struct {
+	Length int
+}
+
+func (p poMaxLineLength) isPrinterOption() {}
+func (p poMaxLineLength) String() string   { return "POMaxLineLength" }
+
+// Logger Start (sent by root loggers when they are created, to mark the start of elapsed time)
+
+type poLoggerStart struct{}
+
+func (p poLoggerStart) isPrinterOption() {}
+func (p poLoggerStart) String() string   { return "poLoggerStart" }
+
+// Color (whether or not to use colors, overriding the environment; see ColorMode)
+
+func POColor(mode ColorMode) poColor {
+	return poColor{Mode: mode}
+}
+
+type poColor struct {
+	Mode ColorMode
+}
+
+func (p poColor) isPrinterOption() {}
+func (p poColor) String() string   { return "POColor" }
+
+// Color Env (sent by root loggers when created, with the color mode requested by the environment)
+
+type poColorEnv struct {
+	Mode ColorMode
+}
+
+func (p poColorEnv) isPrinterOption() {}
+func (p poColorEnv) String() string   { return "poColorEnv" }
+
 // Level
 
 func POLevel(visible bool) poLevel {
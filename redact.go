@@ -0,0 +1,302 @@
+package frog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RedactedText is the value that replaces any redacted field values (unless the rule is changed
+// via ReplaceWith or Hashed).
+const RedactedText = "[REDACTED]"
+
+// RedactRule decides which fields (or parts of field values) should be redacted, and what to
+// replace them with. Create rules with RedactName, RedactGlob, RedactRegexp, or RedactValue.
+type RedactRule struct {
+	matchName   func(name string) bool
+	matchValue  *regexp.Regexp
+	replacement string
+	hash        bool
+}
+
+// RedactName redacts the entire value of any field whose name exactly matches one of the passed
+// in names. Like the other name rules, it also applies to the keys of objects nested within JSON
+// values (e.g. from Any, JSONValue, or ErrChain).
+func RedactName(names ...string) RedactRule {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return RedactRule{
+		matchName:   func(name string) bool { return set[name] },
+		replacement: RedactedText,
+	}
+}
+
+// RedactGlob redacts the entire value of any field whose name matches one of the passed in glob
+// patterns (as defined by path.Match, e.g. "*_token").
+// Panics if any pattern is malformed.
+func RedactGlob(patterns ...string) RedactRule {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			panic("frog: RedactGlob: bad pattern " + QuoteText(pattern))
+		}
+	}
+	return RedactRule{
+		matchName: func(name string) bool {
+			for _, pattern := range patterns {
+				if ok, _ := path.Match(pattern, name); ok {
+					return true
+				}
+			}
+			return false
+		},
+		replacement: RedactedText,
+	}
+}
+
+// RedactRegexp redacts the entire value of any field whose name matches the passed in regular
+// expression.
+func RedactRegexp(re *regexp.Regexp) RedactRule {
+	return RedactRule{
+		matchName:   re.MatchString,
+		replacement: RedactedText,
+	}
+}
+
+// RedactValue redacts any part of a field's value that matches the passed in regular expression,
+// regardless of the field's name (e.g. to catch email addresses wherever they are logged).
+// For string values, only the matching parts are replaced. For any other values (numbers,
+// objects, etc), the entire value is replaced, so that JSON output remains valid.
+func RedactValue(re *regexp.Regexp) RedactRule {
+	return RedactRule{
+		matchValue:  re,
+		replacement: RedactedText,
+	}
+}
+
+// ReplaceWith returns a copy of the rule that replaces redacted values with the passed in text.
+func (r RedactRule) ReplaceWith(text string) RedactRule {
+	r.replacement = text
+	r.hash = false
+	return r
+}
+
+// Hashed returns a copy of the rule that replaces redacted values with a short hash of the
+// original value (e.g. "sha256:1f0a2b3c4d5e"), so that lines with the same value can still be
+// correlated, without revealing the value itself.
+func (r RedactRule) Hashed() RedactRule {
+	r.hash = true
+	return r
+}
+
+func (r RedactRule) replace(s string) string {
+	if !r.hash {
+		return r.replacement
+	}
+	sum := sha256.Sum256([]byte(s))
+	return "sha256:" + hex.EncodeToString(sum[:6])
+}
+
+// redactField applies each rule in turn to the passed in field
+func redactField(f Field, rules []RedactRule) Field {
+	for _, r := range rules {
+		switch {
+		case r.matchName != nil:
+			if r.matchName(f.Name) {
+				f = replaceFieldValue(f, r.replace(f.Value))
+				continue
+			}
+			// the name rules also apply to the keys of any objects within a JSON value
+			if !f.IsJSONString && len(f.Value) > 0 && (f.Value[0] == '{' || f.Value[0] == '[') {
+				if v, ok := redactJSONKeys(f.Value, r); ok {
+					// the TextValue may still show the redacted values, so show the JSON instead
+					f.Value = v
+					f.TextValue = ""
+				}
+			}
+		case r.matchValue != nil:
+			if !r.matchValue.MatchString(f.Value) && !r.matchValue.MatchString(f.TextValue) {
+				continue
+			}
+			if !f.IsJSONString {
+				f = replaceFieldValue(f, r.replace(f.Value))
+				continue
+			}
+			f.Value = r.matchValue.ReplaceAllStringFunc(f.Value, r.replace)
+			f.IsJSONSafe = false // the replacement text may need escaping
+			if f.TextValue != "" {
+				f.TextValue = r.matchValue.ReplaceAllStringFunc(f.TextValue, r.replace)
+			}
+		}
+	}
+	return f
+}
+
+// replaceFieldValue returns a copy of f with its entire value replaced by the passed in string,
+// leaving everything else (e.g. its Kind) alone.
+func replaceFieldValue(f Field, s string) Field {
+	f.Value = s
+	f.TextValue = ""
+	f.IsJSONString = true
+	f.IsJSONSafe = false // the replacement text may need escaping
+	return f
+}
+
+// redactJSONKeys re-encodes the passed in JSON, replacing the value of any object key that
+// matches the rule's name matcher, at any depth. Returns false if nothing was replaced (or if
+// the JSON couldn't be parsed).
+func redactJSONKeys(js string, r RedactRule) (string, bool) {
+	dec := json.NewDecoder(strings.NewReader(js))
+	dec.UseNumber()
+	var sb strings.Builder
+	sb.Grow(len(js))
+	replaced, err := redactJSONValue(&sb, dec, r)
+	if err != nil || !replaced {
+		return js, false
+	}
+	return sb.String(), true
+}
+
+// redactJSONValue reads the next value from dec and writes it to sb, replacing the values of any
+// object keys that match the rule (returns true if anything was replaced).
+func redactJSONValue(sb *strings.Builder, dec *json.Decoder, r RedactRule) (bool, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return false, err
+	}
+	replaced := false
+	switch t := tok.(type) {
+	case json.Delim:
+		isObject := t == '{'
+		sb.WriteRune(rune(t))
+		for i := 0; dec.More(); i++ {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			if isObject {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return false, err
+				}
+				key, _ := keyTok.(string)
+				sb.WriteByte('"')
+				sb.WriteString(escapeStringForJSON(key))
+				sb.WriteString(`":`)
+				if r.matchName(key) {
+					var raw json.RawMessage
+					if err := dec.Decode(&raw); err != nil {
+						return false, err
+					}
+					// hash strings the same way as top level values (i.e. without quotes)
+					orig := string(raw)
+					var str string
+					if json.Unmarshal(raw, &str) == nil {
+						orig = str
+					}
+					sb.WriteByte('"')
+					sb.WriteString(escapeStringForJSON(r.replace(orig)))
+					sb.WriteByte('"')
+					replaced = true
+					continue
+				}
+			}
+			inner, err := redactJSONValue(sb, dec, r)
+			if err != nil {
+				return false, err
+			}
+			replaced = replaced || inner
+		}
+		// the closing delimiter
+		if tok, err = dec.Token(); err != nil {
+			return false, err
+		}
+		sb.WriteRune(rune(tok.(json.Delim)))
+	case string:
+		sb.WriteByte('"')
+		sb.WriteString(escapeStringForJSON(t))
+		sb.WriteByte('"')
+	case json.Number:
+		sb.WriteString(t.String())
+	case bool:
+		sb.WriteString(strconv.FormatBool(t))
+	case nil:
+		sb.WriteString("null")
+	}
+	return replaced, nil
+}
+
+// RedactingLogger is a Logger that adds redaction rules, which the root Logger applies to every
+// field of each line logged through it, including fields added by any parent Loggers.
+type RedactingLogger struct {
+	parent   Logger
+	rules    []RedactRule
+	minLevel atomicLevel // defaults to Transient
+}
+
+func (l *RedactingLogger) Parent() Logger {
+	return l.parent
+}
+
+func (l *RedactingLogger) MinLevel() Level {
+	return l.minLevel.Load()
+}
+
+func (l *RedactingLogger) SetMinLevel(level Level) Logger {
+	l.minLevel.Store(level)
+	return l
+}
+
+func (l *RedactingLogger) LogImpl(level Level, msg string, fielders []Fielder, opts []PrinterOption, d ImplData) {
+	d.MergeMinLevel(l.minLevel.Load())
+	d.MergeRedactions(l.rules)
+	l.parent.LogImpl(level, msg, fielders, opts, d)
+}
+
+func (l *RedactingLogger) Transient(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Transient, msg, fielders, nil, ImplData{})
+	return l
+}
+
+func (l *RedactingLogger) Trace(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Trace, msg, fielders, nil, ImplData{})
+	return l
+}
+
+func (l *RedactingLogger) Verbose(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Verbose, msg, fielders, nil, ImplData{})
+	return l
+}
+
+func (l *RedactingLogger) Info(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Info, msg, fielders, nil, ImplData{})
+	return l
+}
+
+func (l *RedactingLogger) Warning(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Warning, msg, fielders, nil, ImplData{})
+	return l
+}
+
+func (l *RedactingLogger) Error(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Error, msg, fielders, nil, ImplData{})
+	return l
+}
+
+func (l *RedactingLogger) Fatal(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Fatal, msg, fielders, nil, ImplData{})
+	fatalExit(l)
+	return l
+}
+
+func (l *RedactingLogger) Log(level Level, msg string, fielders ...Fielder) Logger {
+	l.LogImpl(level, msg, fielders, nil, ImplData{})
+	if level == Fatal {
+		fatalExit(l)
+	}
+	return l
+}
@@ -0,0 +1,150 @@
+package frog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func Test_RedactingLoggerInterfaces(t *testing.T) {
+	var _ Logger = &RedactingLogger{}
+	var _ ChildLogger = &RedactingLogger{}
+}
+
+func Test_Redaction(t *testing.T) {
+	email := regexp.MustCompile(`[a-z]+@[a-z]+\.com`)
+	cases := []struct {
+		Name     string
+		Rules    []RedactRule
+		Fielders []Fielder
+		Expected string
+	}{
+		{
+			"name",
+			[]RedactRule{RedactName("token", "password")},
+			[]Fielder{String("token", "abc123"), Int("password", 42)},
+			`user=bob token=[REDACTED] password=[REDACTED]`,
+		},
+		{
+			"glob",
+			[]RedactRule{RedactGlob("*_key", "secret*")},
+			[]Fielder{String("api_key", "abc"), String("secrets", "xyz"), String("keyring", "ok")},
+			`user=bob api_key=[REDACTED] secrets=[REDACTED] keyring=ok`,
+		},
+		{
+			"regexp",
+			[]RedactRule{RedactRegexp(regexp.MustCompile(`(?i)^auth`))},
+			[]Fielder{String("Authorization", "Bearer abc"), String("author", "me")},
+			`user=bob Authorization=[REDACTED] author=[REDACTED]`,
+		},
+		{
+			"value",
+			[]RedactRule{RedactValue(email)},
+			[]Fielder{String("note", "mail bob@example.com or amy@example.com"), JSONValue("obj", map[string]string{"to": "amy@example.com"})},
+			`user=bob note="mail [REDACTED] or [REDACTED]" obj=[REDACTED]`,
+		},
+		{
+			"parent fields",
+			[]RedactRule{RedactName("user").ReplaceWith("***")},
+			[]Fielder{String("other", "x")},
+			`user=*** other=x`,
+		},
+		{
+			"hashed",
+			[]RedactRule{RedactName("token").Hashed()},
+			[]Fielder{String("token", "abc123")},
+			`user=bob token=sha256:6ca13d52ca70`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			root := NewUnbuffered(&buf, &TextPrinter{})
+			log := WithRedaction(WithFields(root, String("user", "bob")), tc.Rules...)
+			log.Info("", tc.Fielders...)
+			if actual := strings.TrimSpace(buf.String()); actual != tc.Expected {
+				t.Errorf("\nexpected: %s\nactual:   %s", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func Test_RedactionJSON(t *testing.T) {
+	var buf bytes.Buffer
+	root := NewUnbuffered(&buf, newTestJSONPrinter())
+	log := WithRedaction(root, RedactName("n"), RedactValue(regexp.MustCompile(`"`)).Hashed())
+	WithFields(log, Int("n", 1)).Info("", String("quoted", `say "hi"`), ErrChain(nil))
+
+	expected := testJSONLine("info", "", `"n":"[REDACTED]","quoted":"say sha256:8a331fdde703hisha256:8a331fdde703","error":null`)
+	actual := strings.TrimSpace(buf.String())
+	if actual != expected {
+		t.Errorf("\nexpected: %s\nactual:   %s", expected, actual)
+	}
+	var target map[string]interface{}
+	if err := json.Unmarshal([]byte(actual), &target); err != nil {
+		t.Errorf("expected valid JSON, got %v", err)
+	}
+}
+
+func Test_RedactionJSONEscapesReplacement(t *testing.T) {
+	var buf bytes.Buffer
+	root := NewUnbuffered(&buf, newTestJSONPrinter())
+	log := WithRedaction(root, RedactValue(regexp.MustCompile(`6`)).ReplaceWith(`"q"\`))
+	log.Info("", String("b", "616263"))
+
+	expected := testJSONLine("info", "", `"b":"\"q\"\\1\"q\"\\2\"q\"\\3"`)
+	actual := strings.TrimSpace(buf.String())
+	if actual != expected {
+		t.Errorf("\nexpected: %s\nactual:   %s", expected, actual)
+	}
+	var target map[string]interface{}
+	if err := json.Unmarshal([]byte(actual), &target); err != nil {
+		t.Errorf("expected valid JSON, got %v", err)
+	}
+}
+
+type testRedactCreds struct {
+	User  string
+	Token string
+}
+
+func Test_RedactionNestedKeys(t *testing.T) {
+	creds := testRedactCreds{User: "bob", Token: "abc123"}
+	rules := []RedactRule{RedactName("Token", "secret", "text")}
+	fielders := []Fielder{
+		Any("creds", creds),
+		JSONValue("list", []interface{}{creds, map[string]int{"secret": 7}}),
+		ErrChain(testStatusError{Code: 404}),
+	}
+
+	expectedText := `creds={"User":"bob","Token":"[REDACTED]"} list=[{"User":"bob","Token":"[REDACTED]"},{"secret":"[REDACTED]"}] error={"msg":"status 404","type":"frog.testStatusError","fields":{"code":404,"text":"[REDACTED]"}}`
+	var buf bytes.Buffer
+	WithRedaction(NewUnbuffered(&buf, &TextPrinter{}), rules...).Info("", fielders...)
+	if actual := strings.TrimSpace(buf.String()); actual != expectedText {
+		t.Errorf("text:\nexpected: %s\nactual:   %s", expectedText, actual)
+	}
+
+	expectedJSON := testJSONLine("info", "", `"creds":{"User":"bob","Token":"[REDACTED]"},"list":[{"User":"bob","Token":"[REDACTED]"},{"secret":"[REDACTED]"}],"error":{"msg":"status 404","type":"frog.testStatusError","fields":{"code":404,"text":"[REDACTED]"}}`)
+	buf.Reset()
+	WithRedaction(NewUnbuffered(&buf, newTestJSONPrinter()), rules...).Info("", fielders...)
+	actual := strings.TrimSpace(buf.String())
+	if actual != expectedJSON {
+		t.Errorf("json:\nexpected: %s\nactual:   %s", expectedJSON, actual)
+	}
+	var target map[string]interface{}
+	if err := json.Unmarshal([]byte(actual), &target); err != nil {
+		t.Errorf("expected valid JSON, got %v", err)
+	}
+}
+
+func Test_RedactionKeepsKind(t *testing.T) {
+	f := redactField(Err(errors.New("oops")).Field(), []RedactRule{RedactName("error")})
+	expected := Field{Name: "error", Value: RedactedText, IsJSONString: true, Kind: KindError}
+	if f != expected {
+		t.Errorf("\nexpected: %#v\nactual:   %#v", expected, f)
+	}
+}
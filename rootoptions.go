@@ -0,0 +1,77 @@
+package frog
+
+// RootOption configures a root Logger when it is created (see NewBuffered and NewUnbuffered).
+type RootOption interface {
+	isRootOption()
+	String() string
+}
+
+// rootConfig holds the settings from a root Logger's RootOptions
+type rootConfig struct {
+	clock         Clock // if nil, the Printer's own clock is left alone
+	fatalExitCode int
+}
+
+func newRootConfig(opts []RootOption) rootConfig {
+	cfg := rootConfig{fatalExitCode: DefaultFatalExitCode}
+	for _, o := range opts {
+		switch ot := o.(type) {
+		case roClock:
+			cfg.clock = ot.Clock
+		case roFatalExitCode:
+			cfg.fatalExitCode = ot.Code
+		}
+	}
+	return cfg
+}
+
+// printerOptions returns the options a root Logger sets on its Printer when it is created.
+func (cfg rootConfig) printerOptions() []PrinterOption {
+	var opts []PrinterOption
+	if cfg.clock != nil {
+		// before poLoggerStart, so that the start time comes from the same Clock
+		opts = append(opts, POClock(cfg.clock))
+	}
+	return append(opts, poLoggerStart{}, poColorEnv{Mode: ColorModeFromEnv()})
+}
+
+// printerCopier is implemented by this package's Printers, so that a root Logger can set its options
+// on its own copy, and leave the Printer that was passed in untouched (it may be shared by other roots).
+type printerCopier interface {
+	copyPrinter() Printer
+}
+
+// preparePrinter returns the Printer a root Logger should use, with the root's options applied.
+// Printers from outside this package can't be copied, so their options are set in place.
+func (cfg rootConfig) preparePrinter(prn Printer) Printer {
+	if pc, ok := prn.(printerCopier); ok {
+		prn = pc.copyPrinter()
+	}
+	return prn.SetOptions(cfg.printerOptions()...)
+}
+
+// Clock (the source of the current time for the root Logger and its Printer)
+
+func ROClock(c Clock) RootOption {
+	return roClock{Clock: c}
+}
+
+type roClock struct {
+	Clock Clock
+}
+
+func (p roClock) isRootOption()  {}
+func (p roClock) String() string { return "ROClock" }
+
+// Fatal exit code (the code the process exits with after a Fatal line is logged)
+
+func ROFatalExitCode(code int) RootOption {
+	return roFatalExitCode{Code: code}
+}
+
+type roFatalExitCode struct {
+	Code int
+}
+
+func (p roFatalExitCode) isRootOption()  {}
+func (p roFatalExitCode) String() string { return "ROFatalExitCode" }
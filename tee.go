@@ -6,7 +6,7 @@ type TeeLogger struct {
 	Primary   Logger // Anchors are only supported though this logger
 	Secondary Logger
 
-	minLevel Level // defaults to Transient
+	minLevel atomicLevel // defaults to Transient
 }
 
 func NewRootTee(a RootLogger, b RootLogger) (*TeeLogger, func()) {
@@ -29,16 +29,16 @@ func (l *TeeLogger) Parent() Logger {
 }
 
 func (n *TeeLogger) MinLevel() Level {
-	return n.minLevel
+	return n.minLevel.Load()
 }
 
 func (n *TeeLogger) SetMinLevel(level Level) Logger {
-	n.minLevel = level
+	n.minLevel.Store(level)
 	return n
 }
 
 func (l *TeeLogger) LogImpl(level Level, msg string, fielders []Fielder, opts []PrinterOption, d ImplData) {
-	d.MergeMinLevel(l.minLevel) // ensure our minLevel is taken into account
+	d.MergeMinLevel(l.minLevel.Load()) // ensure our minLevel is taken into account
 
 	l.Primary.LogImpl(level, msg, fielders, opts, d)
 
@@ -51,6 +51,11 @@ func (l *TeeLogger) Transient(msg string, fielders ...Fielder) Logger {
 	return l
 }
 
+func (l *TeeLogger) Trace(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Trace, msg, fielders, nil, ImplData{})
+	return l
+}
+
 func (l *TeeLogger) Verbose(msg string, fielders ...Fielder) Logger {
 	l.LogImpl(Verbose, msg, fielders, nil, ImplData{})
 	return l
@@ -71,7 +76,16 @@ func (l *TeeLogger) Error(msg string, fielders ...Fielder) Logger {
 	return l
 }
 
+func (l *TeeLogger) Fatal(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Fatal, msg, fielders, nil, ImplData{})
+	fatalExit(l)
+	return l
+}
+
 func (l *TeeLogger) Log(level Level, msg string, fielders ...Fielder) Logger {
 	l.LogImpl(level, msg, fielders, nil, ImplData{})
+	if level == Fatal {
+		fatalExit(l)
+	}
 	return l
 }
@@ -0,0 +1,502 @@
+package frog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/danbrakeley/ansi"
+)
+
+// TemplatePrinter is a Printer that renders each line according to a layout template, for example:
+//
+//	{time:15:04:05} {level:short|<5}{? {logger} |} {msg|<40} {fields}
+//
+// Anything outside of curly braces is copied as-is (use "{{" and "}}" for literal braces, though
+// note that inside a conditional element, "}" always closes an element).
+// Inside curly braces is an element, with an optional argument after a ':', and zero or more
+// modifiers, each preceded by a '|'.
+//
+// Elements:
+//   - time[:layout] - the current time, using the Go time layout given (defaults to the same layout as TextPrinter)
+//   - level[:style] - the level, where style is one of "bracket" ("[nfo]", the default), "short" ("nfo"),
+//     "name" ("info"), or "upper" ("INFO")
+//   - msg - the message
+//   - fields - all fields, rendered as name=value pairs (except for fields used by field or logger elements)
+//   - field:name - just the value of the named field
+//   - logger - shorthand for field:logger
+//   - caller[:part] - where the line was logged from, where part is "file" (just the file),
+//     "func" (just the function name), or defaults to the file and line (e.g. "frog/printer.go:123")
+//
+// Modifiers:
+//   - <n or n - pad with spaces on the right until at least n runes wide
+//   - >n - pad with spaces on the left until at least n runes wide
+//   - primary, secondary - use the level's primary or secondary color from the Palette
+//   - nocolor - don't color this element
+//
+// By default, msg, field, and logger use the primary color, while everything else uses the
+// secondary color (including any literal text).
+//
+// Conditional elements are written as "{?...}", where everything inside the outermost braces is
+// itself a template. The conditional is only rendered if every element inside it rendered
+// something. In the example above, " {logger} |" is only rendered for lines with a "logger" field.
+//
+// TemplatePrinter respects the same PrinterOptions as TextPrinter, except for POFieldIndent,
+// POMsgLeftFieldsRight, and POFieldsLeftMsgRight, which are handled by the template itself.
+// Caller information is captured whenever the template includes a caller element (unless it is
+// turned off via POCaller(false)).
+type TemplatePrinter struct {
+	text  TextPrinter
+	nodes []tmplNode
+}
+
+// NewTemplatePrinter parses the passed layout and returns a TemplatePrinter that will use it to
+// render each line. The returned printer defaults to showing the time and level (if they are part
+// of the layout), and uses no colors. Use SetOptions to change these defaults.
+func NewTemplatePrinter(layout string) (*TemplatePrinter, error) {
+	nodes, err := parseTemplate(layout)
+	if err != nil {
+		return nil, err
+	}
+	return &TemplatePrinter{
+		text:  TextPrinter{printTime: true, printLevel: true, printCaller: hasTemplateCaller(nodes)},
+		nodes: nodes,
+	}, nil
+}
+
+func (p *TemplatePrinter) copyPrinter() Printer {
+	cp := *p
+	return &cp
+}
+
+func (p *TemplatePrinter) SetOptions(opts ...PrinterOption) Printer {
+	p.text.SetOptions(opts...)
+	return p
+}
+
+func (p *TemplatePrinter) Render(level Level, opts []PrinterOption, msg string, fields []Field) string {
+	// see TextPrinter.Render for why we make a copy
+	if len(opts) > 0 {
+		tmp := *p
+		tmp.text.SetOptions(opts...)
+		return tmp.Render(level, nil, msg, fields)
+	}
+
+	fields = resolveDuplicates(fields, p.text.duplicates)
+
+	if p.text.maxFieldLength > 0 || p.text.maxLineLength > 0 {
+		tmp := *p
+		tmp.text.clock = fixedClock(p.text.currentTime())
+		return renderWithLimits(p.text.maxLineLength, p.text.maxFieldLength, msg, fields, func(msg string, fields []Field) string {
+			return tmp.render(level, msg, fields)
+		})
+	}
+	return p.render(level, msg, fields)
+}
+
+func (p *TemplatePrinter) render(level Level, msg string, fields []Field) string {
+	useColor, colorPrimary, colorSecondary := p.text.colors(level)
+
+	r := tmplRender{
+		prn:            &p.text,
+		level:          level,
+		msg:            escapeMessageForTerminal(trimNewlines(msg)),
+		fields:         fields,
+		useColor:       useColor,
+		colorPrimary:   colorPrimary,
+		colorSecondary: colorSecondary,
+	}
+	r.used = findNamedFields(p.nodes, fields, nil)
+
+	var sb strings.Builder
+	sb.Grow(256)
+	r.renderNodes(&sb, p.nodes)
+	p.text.writeStack(&sb, level, useColor, colorSecondary)
+
+	if useColor {
+		sb.WriteString(ansi.Reset)
+	}
+
+	return p.text.cropTransient(level, sb.String())
+}
+
+type tmplKind byte
+
+const (
+	tkLiteral tmplKind = iota
+	tkTime
+	tkLevel
+	tkMsg
+	tkFields
+	tkField
+	tkCaller
+	tkGroup
+)
+
+type tmplColor byte
+
+const (
+	tcDefault tmplColor = iota
+	tcPrimary
+	tcSecondary
+	tcNone
+)
+
+type tmplNode struct {
+	kind       tmplKind
+	arg        string // literal text, time layout, level style, or field name
+	width      int
+	alignRight bool
+	color      tmplColor
+	children   []tmplNode // only used by tkGroup
+}
+
+func parseTemplate(layout string) ([]tmplNode, error) {
+	nodes, rest, err := parseTemplateNodes(layout, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("template: unexpected '}' at offset %d", len(layout)-len(rest))
+	}
+	return nodes, nil
+}
+
+// parseTemplateNodes parses until it runs out of input, or (if inGroup is true) until it finds
+// the '}' that closes the current group. It returns the parsed nodes and the unparsed remainder
+// (starting with the closing '}', if any).
+func parseTemplateNodes(s string, inGroup bool) ([]tmplNode, string, error) {
+	var nodes []tmplNode
+	var lit strings.Builder
+
+	fnFlushLiteral := func() {
+		if lit.Len() > 0 {
+			nodes = append(nodes, tmplNode{kind: tkLiteral, arg: lit.String()})
+			lit.Reset()
+		}
+	}
+
+	for len(s) > 0 {
+		switch {
+		case strings.HasPrefix(s, "{{"):
+			lit.WriteByte('{')
+			s = s[2:]
+		case strings.HasPrefix(s, "}}") && !inGroup:
+			lit.WriteByte('}')
+			s = s[2:]
+		case s[0] == '}':
+			if !inGroup {
+				return nil, s, nil
+			}
+			fnFlushLiteral()
+			return nodes, s, nil
+		case strings.HasPrefix(s, "{?"):
+			fnFlushLiteral()
+			children, rest, err := parseTemplateNodes(s[2:], true)
+			if err != nil {
+				return nil, "", err
+			}
+			if len(rest) == 0 {
+				return nil, "", fmt.Errorf("template: conditional element is missing a closing '}'")
+			}
+			nodes = append(nodes, tmplNode{kind: tkGroup, children: children})
+			s = rest[1:]
+		case s[0] == '{':
+			fnFlushLiteral()
+			end := strings.IndexByte(s, '}')
+			if end == -1 {
+				return nil, "", fmt.Errorf("template: element is missing a closing '}': %s", s)
+			}
+			n, err := parseTemplateElement(s[1:end])
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, n)
+			s = s[end+1:]
+		default:
+			lit.WriteByte(s[0])
+			s = s[1:]
+		}
+	}
+
+	if inGroup {
+		return nil, "", fmt.Errorf("template: conditional element is missing a closing '}'")
+	}
+	fnFlushLiteral()
+	return nodes, "", nil
+}
+
+func parseTemplateElement(s string) (tmplNode, error) {
+	var n tmplNode
+
+	mods := strings.Split(s, "|")
+	name := mods[0]
+	mods = mods[1:]
+
+	if idx := strings.IndexByte(name, ':'); idx >= 0 {
+		name, n.arg = name[:idx], name[idx+1:]
+	}
+
+	switch name {
+	case "time":
+		n.kind = tkTime
+	case "level":
+		n.kind = tkLevel
+		switch n.arg {
+		case "", "bracket", "short", "name", "upper":
+		default:
+			return n, fmt.Errorf("template: unknown level style %q (expected bracket, short, name, or upper)", n.arg)
+		}
+	case "msg":
+		n.kind = tkMsg
+	case "fields":
+		n.kind = tkFields
+	case "field":
+		n.kind = tkField
+		if len(n.arg) == 0 {
+			return n, fmt.Errorf("template: field element requires a field name (e.g. {field:name})")
+		}
+	case "logger":
+		n.kind = tkField
+		n.arg = "logger"
+	case "caller":
+		n.kind = tkCaller
+		switch n.arg {
+		case "", "file", "func":
+		default:
+			return n, fmt.Errorf("template: unknown caller part %q (expected file or func)", n.arg)
+		}
+	default:
+		return n, fmt.Errorf("template: unknown element %q", name)
+	}
+
+	for _, mod := range mods {
+		switch mod {
+		case "primary":
+			n.color = tcPrimary
+		case "secondary":
+			n.color = tcSecondary
+		case "nocolor":
+			n.color = tcNone
+		default:
+			width := mod
+			if strings.HasPrefix(width, "<") {
+				width = width[1:]
+			} else if strings.HasPrefix(width, ">") {
+				width = width[1:]
+				n.alignRight = true
+			}
+			w, err := strconv.Atoi(width)
+			if err != nil || w < 0 {
+				return n, fmt.Errorf("template: unknown modifier %q in element %q", mod, s)
+			}
+			n.width = w
+		}
+	}
+
+	return n, nil
+}
+
+func hasTemplateCaller(nodes []tmplNode) bool {
+	for _, n := range nodes {
+		if n.kind == tkCaller || (n.kind == tkGroup && hasTemplateCaller(n.children)) {
+			return true
+		}
+	}
+	return false
+}
+
+// findNamedFields returns the set of fields (by index) that are rendered by field elements, so
+// that those fields can be left out of the fields element. A field element renders the first field
+// with its name, so any later fields with the same name (e.g. a user's "logger" field on a line
+// from a named Logger) are still rendered by the fields element.
+func findNamedFields(nodes []tmplNode, fields []Field, used map[int]bool) map[int]bool {
+	for _, n := range nodes {
+		switch n.kind {
+		case tkField:
+			for i, f := range fields {
+				if f.Name == n.arg {
+					if used == nil {
+						used = make(map[int]bool)
+					}
+					used[i] = true
+					break
+				}
+			}
+		case tkGroup:
+			used = findNamedFields(n.children, fields, used)
+		}
+	}
+	return used
+}
+
+// tmplRender holds the state needed while rendering a single line
+type tmplRender struct {
+	prn            *TextPrinter
+	level          Level
+	msg            string
+	fields         []Field
+	used           map[int]bool
+	useColor       bool
+	colorPrimary   string
+	colorSecondary string
+
+	caller         Caller
+	callerCaptured bool
+}
+
+func (r *tmplRender) renderNodes(sb *strings.Builder, nodes []tmplNode) {
+	for _, n := range nodes {
+		if n.kind == tkGroup {
+			var group strings.Builder
+			if r.renderGroup(&group, n.children) {
+				sb.WriteString(group.String())
+			}
+			continue
+		}
+		r.renderNode(sb, n)
+	}
+}
+
+// renderGroup renders the nodes into sb, and returns false if any non-literal nodes were empty.
+func (r *tmplRender) renderGroup(sb *strings.Builder, nodes []tmplNode) bool {
+	for _, n := range nodes {
+		if n.kind == tkGroup {
+			var group strings.Builder
+			if r.renderGroup(&group, n.children) {
+				sb.WriteString(group.String())
+			}
+			continue
+		}
+		if !r.renderNode(sb, n) && n.kind != tkLiteral {
+			return false
+		}
+	}
+	return true
+}
+
+// renderNode renders a single non-group node, and returns false if there was nothing to render.
+func (r *tmplRender) renderNode(sb *strings.Builder, n tmplNode) bool {
+	color := r.colorSecondary
+	switch n.color {
+	case tcPrimary:
+		color = r.colorPrimary
+	case tcNone:
+		color = ""
+	case tcDefault:
+		if n.kind == tkMsg || n.kind == tkField {
+			color = r.colorPrimary
+		}
+	}
+
+	if n.kind == tkFields {
+		var fields []Field
+		if len(r.used) == 0 {
+			fields = r.fields
+		} else {
+			fields = make([]Field, 0, len(r.fields))
+			for i, f := range r.fields {
+				if !r.used[i] {
+					fields = append(fields, f)
+				}
+			}
+		}
+		if len(fields) == 0 {
+			return false
+		}
+		var tmp strings.Builder
+		count := writeTextFields(&tmp, fields, r.useColor && n.color != tcNone, r.colorPrimary, r.colorSecondary, r.prn.valueColors)
+		r.writePadded(sb, n, tmp.String(), count)
+		return true
+	}
+
+	var s string
+	switch n.kind {
+	case tkLiteral:
+		s = n.arg
+	case tkTime:
+		if r.prn.printTime {
+			s = r.prn.timestampLayout(n.arg)
+		}
+	case tkLevel:
+		if r.prn.printLevel {
+			s = levelStyle(r.prn.levelLabel(r.level), r.level, n.arg)
+		}
+	case tkMsg:
+		s = r.msg
+	case tkField:
+		for _, f := range r.fields {
+			if f.Name == n.arg {
+				s = textFieldValue(f)
+				if n.color == tcDefault {
+					color = r.prn.valueColors.valueColor(f, color)
+				}
+				break
+			}
+		}
+	case tkCaller:
+		if c, ok := r.captureCaller(); ok {
+			switch n.arg {
+			case "file":
+				s = c.File
+			case "func":
+				s = c.Function
+			default:
+				s = c.FileLine()
+			}
+		}
+	}
+	if len(s) == 0 {
+		return false
+	}
+
+	if r.useColor && len(color) > 0 {
+		sb.WriteString(color)
+	} else if r.useColor {
+		sb.WriteString(ansi.Reset)
+	}
+	r.writePadded(sb, n, s, utf8.RuneCountInString(s))
+	return true
+}
+
+// captureCaller captures the caller the first time it is called, then returns the same result
+// for any subsequent calls.
+func (r *tmplRender) captureCaller() (Caller, bool) {
+	if !r.prn.printCaller {
+		return Caller{}, false
+	}
+	if !r.callerCaptured {
+		r.caller, _ = captureCaller(r.prn.callerSkip, r.prn.callerPath)
+		r.callerCaptured = true
+	}
+	return r.caller, len(r.caller.File) > 0
+}
+
+func (r *tmplRender) writePadded(sb *strings.Builder, n tmplNode, s string, visibleRunes int) {
+	pad := n.width - visibleRunes
+	if n.alignRight {
+		for i := 0; i < pad; i++ {
+			sb.WriteByte(' ')
+		}
+	}
+	sb.WriteString(s)
+	if !n.alignRight {
+		for i := 0; i < pad; i++ {
+			sb.WriteByte(' ')
+		}
+	}
+}
+
+// levelStyle converts a bracketed level label (e.g. "[nfo]") into the requested style.
+func levelStyle(label string, level Level, style string) string {
+	switch style {
+	case "short":
+		return strings.TrimSuffix(strings.TrimPrefix(label, "["), "]")
+	case "name":
+		return level.String()
+	case "upper":
+		return strings.ToUpper(level.String())
+	}
+	return label
+}
@@ -0,0 +1,132 @@
+package frog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_TemplatePrinterInterfaces(t *testing.T) {
+	var _ Printer = &TemplatePrinter{}
+}
+
+func Test_TemplatePrinter(t *testing.T) {
+	cases := []struct {
+		Name   string
+		DoWork func(Logger)
+	}{
+		{"min-level", minLevel},
+		{"fields", fields},
+		{"with-fields-and-opts", withFieldsAndOptions},
+		{"with-quotes", withQuotes},
+		{"template-elements", templateElements},
+	}
+
+	const layout = "{level:short|<4}{? [{logger}]} {msg|<30}{? {fields}}"
+
+	for _, tc := range cases {
+		t.Run(tc.Name+".tmpl", func(t *testing.T) {
+			prn, err := NewTemplatePrinter(layout)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			log := NewUnbuffered(&buf, prn)
+			tc.DoWork(log)
+			log.Close()
+			AssertGolden(t, tc.Name+".tmpl", buf.Bytes())
+		})
+		t.Run(tc.Name+".tmpl.color", func(t *testing.T) {
+			prn, err := NewTemplatePrinter(layout)
+			if err != nil {
+				t.Fatal(err)
+			}
+			prn.SetOptions(POPalette(DefaultPalette))
+			var buf bytes.Buffer
+			log := NewUnbuffered(&buf, prn)
+			tc.DoWork(log)
+			log.Close()
+			AssertGolden(t, tc.Name+".tmpl.color", buf.Bytes())
+		})
+	}
+}
+
+func Test_TemplatePrinterRender(t *testing.T) {
+	cases := []struct {
+		Layout   string
+		Level    Level
+		Msg      string
+		Fields   []Field
+		Expected string
+	}{
+		{"{msg}", Info, "hello", nil, "hello"},
+		{"{level} {msg}", Warning, "hello", nil, "[WRN] hello"},
+		{"{level:short} {msg}", Warning, "hello", nil, "WRN hello"},
+		{"{level:name} {msg}", Warning, "hello", nil, "warning hello"},
+		{"{level:upper} {msg}", Warning, "hello", nil, "WARNING hello"},
+		{"{level:name|<8}|", Info, "", nil, "info    |"},
+		{"{level:name|8}|", Info, "", nil, "info    |"},
+		{"{level:name|>8}|", Info, "", nil, "    info|"},
+		{"{msg|<3}|", Info, "hello", nil, "hello|"},
+		{"{{{msg}}}", Info, "hello", nil, "{hello}"},
+		{"{msg} {fields}", Info, "hello", []Field{{Name: "a", Value: "1"}, {Name: "b", Value: "x y", IsJSONString: true}}, `hello a=1 b="x y"`},
+		{"{field:b}: {msg} {fields}", Info, "hello", []Field{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}}, "2: hello a=1"},
+		{"{?[{logger}] }{msg}", Info, "hello", nil, "hello"},
+		{"{?[{logger}] }{msg}", Info, "hello", []Field{{Name: "logger", Value: "db", IsJSONString: true}}, "[db] hello"},
+		{"{msg}{? ({fields})}", Info, "hello", nil, "hello"},
+		{"{msg}{? ({fields})}", Info, "hello", []Field{{Name: "a", Value: "1"}}, "hello (a=1)"},
+		{"{msg}{? {field:a}{? {field:b}}}", Info, "hi", []Field{{Name: "a", Value: "1"}}, "hi 1"},
+		{"{msg}{? {field:a}{? {field:b}}}", Info, "hi", []Field{{Name: "b", Value: "2"}}, "hi"},
+	}
+
+	for _, tc := range cases {
+		prn, err := NewTemplatePrinter(tc.Layout)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.Layout, err)
+			continue
+		}
+		actual := prn.Render(tc.Level, nil, tc.Msg, tc.Fields)
+		if actual != tc.Expected {
+			t.Errorf("%q: expected %q, got %q", tc.Layout, tc.Expected, actual)
+		}
+	}
+}
+
+func Test_TemplatePrinterOptions(t *testing.T) {
+	prn, err := NewTemplatePrinter("{?{time} }{?{level} }{msg}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := prn.Render(Info, []PrinterOption{POTime(false), POLevel(false)}, "hello", nil)
+	if actual != "hello" {
+		t.Errorf("expected time and level to be hidden, got %q", actual)
+	}
+	actual = prn.Render(Info, []PrinterOption{POTime(false)}, "hello", nil)
+	if actual != "[nfo] hello" {
+		t.Errorf("expected time to be hidden, got %q", actual)
+	}
+}
+
+func Test_TemplatePrinterErrors(t *testing.T) {
+	for _, layout := range []string{
+		"{msg",
+		"msg}",
+		"{unknown}",
+		"{level:tiny}",
+		"{field}",
+		"{msg|wide}",
+		"{msg|-3}",
+		"{? {msg}",
+	} {
+		if _, err := NewTemplatePrinter(layout); err == nil {
+			t.Errorf("%q: expected an error, but got none", layout)
+		}
+	}
+}
+
+func templateElements(l Logger) {
+	l.SetMinLevel(Transient)
+	l.Info("no logger")
+	WithFields(l, String("logger", "db")).Info("with logger", Int("conns", 3))
+	WithFields(l, String("logger", "http.server")).Warning("with a long logger name", String("path", "/"))
+	l.Error("no logger again", Bool("done", true))
+}
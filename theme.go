@@ -0,0 +1,296 @@
+package frog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EnvVarPalette is the environment variable that New checks for a theme, which can be either the
+// name of a built-in theme (e.g. "dark") or a theme spec (see ParseTheme).
+const EnvVarPalette = "FROG_PALETTE"
+
+// Theme is a Palette, along with optional labels for each level (empty labels are left as-is).
+type Theme struct {
+	Palette Palette
+	Labels  LevelLabels
+}
+
+var themes = map[string]Theme{
+	"default": {Palette: DefaultPalette},
+	"dark":    {Palette: DarkPalette},
+	"light-background": {Palette: Palette{
+		Transient: {DarkGreen, DarkGray},
+		Trace:     {DarkGray, DarkGray},
+		Verbose:   {DarkCyan, DarkBlue},
+		Info:      {Black, DarkGray},
+		Warning:   {DarkYellow.Bold(), DarkYellow},
+		Error:     {DarkRed.Bold(), DarkRed},
+		Fatal:     {White.Bold().Bg(DarkRed), DarkRed.Bold()},
+	}},
+	"high-contrast": {Palette: Palette{
+		Transient: {White, LightGray},
+		Trace:     {LightGray, LightGray},
+		Verbose:   {Cyan.Bold(), Cyan},
+		Info:      {White.Bold(), White},
+		Warning:   {Black.Bg(Yellow), Yellow.Bold()},
+		Error:     {White.Bold().Bg(DarkRed), Red.Bold()},
+		Fatal:     {Yellow.Bold().Bg(DarkRed), Red.Bold().Underline()},
+	}},
+	// uses colors from the Okabe-Ito palette, which avoids relying on telling red from green
+	"colorblind-safe": {Palette: Palette{
+		Transient: {DarkGray, DarkGray},
+		Trace:     {LightGray, DarkGray},
+		Verbose:   {RGB(86, 180, 233), RGB(0, 114, 178)}, // sky blue, blue
+		Info:      {White, LightGray},
+		Warning:   {RGB(240, 228, 66), RGB(230, 159, 0)},           // yellow, orange
+		Error:     {RGB(204, 121, 167).Bold(), RGB(204, 121, 167)}, // reddish purple
+		Fatal:     {White.Bold().Bg(RGB(204, 121, 167)), RGB(204, 121, 167).Bold()},
+	}},
+}
+
+// ThemeNames returns the names of the built-in themes, sorted alphabetically.
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ThemeNamed returns the built-in theme with the passed in name, or false if there isn't one.
+func ThemeNamed(name string) (Theme, bool) {
+	t, ok := themes[strings.ToLower(strings.TrimSpace(name))]
+	return t, ok
+}
+
+// LoadTheme reads the file at the given path and parses it with ParseTheme.
+func LoadTheme(path string) (Theme, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Theme{}, fmt.Errorf("frog: unable to load theme: %w", err)
+	}
+	t, err := ParseTheme(string(b))
+	if err != nil {
+		return Theme{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+// ThemeFromEnv returns the theme specified by the FROG_PALETTE environment variable, which can be
+// either the name of a built-in theme, or a theme spec (see ParseTheme). If the variable isn't set,
+// it returns false.
+func ThemeFromEnv() (Theme, bool, error) {
+	spec, ok := os.LookupEnv(EnvVarPalette)
+	if !ok || len(strings.TrimSpace(spec)) == 0 {
+		return Theme{}, false, nil
+	}
+	if t, ok := ThemeNamed(spec); ok {
+		return t, true, nil
+	}
+	t, err := ParseTheme(spec)
+	if err != nil {
+		return Theme{}, false, fmt.Errorf("%s: %w", EnvVarPalette, err)
+	}
+	return t, true, nil
+}
+
+// ParseTheme parses a theme spec, which is a list of settings separated by newlines or semicolons,
+// where each setting is "key = value", and "#" starts a comment. For example:
+//
+//	base = dark              # start from a built-in theme (otherwise starts from "default")
+//	info = white, lightgray  # primary and (optionally) secondary colors for a level
+//	error = red+bold, darkred
+//	warning.label = [WARN]   # the label for a level
+//
+// Levels are named as in Level.String. Colors are any of:
+//   - a basic color name (e.g. "red", "darkred", "lightgray")
+//   - a 256-color index (e.g. "208")
+//   - a 24-bit color (e.g. "#ff8000")
+//   - "default", for the terminal's default color
+//
+// followed by an optional background ("/" and a color), and optional styles ("+" and one of bold,
+// dim, italic, or underline), e.g. "white/darkred+bold+underline".
+func ParseTheme(spec string) (Theme, error) {
+	t := themes["default"]
+	spec = strings.ReplaceAll(spec, ";", "\n")
+	for i, line := range strings.Split(spec, "\n") {
+		line = strings.TrimSpace(stripComment(line))
+		if len(line) == 0 {
+			continue
+		}
+		if err := t.parseSetting(line); err != nil {
+			return Theme{}, fmt.Errorf("frog: theme line %d (%q): %w", i+1, line, err)
+		}
+	}
+	return t, nil
+}
+
+// stripComment removes anything after the first '#' that doesn't start a 24-bit color
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && !isColorHash(line, i) {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// isColorHash returns true if the '#' at the given index starts a 24-bit color, rather than a comment
+func isColorHash(line string, hash int) bool {
+	if hash+7 > len(line) {
+		return false
+	}
+	if _, err := strconv.ParseUint(line[hash+1:hash+7], 16, 32); err != nil {
+		return false
+	}
+	return hash+7 == len(line) || strings.IndexByte(" \t,/+", line[hash+7]) >= 0
+}
+
+func (t *Theme) parseSetting(line string) error {
+	eq := strings.IndexByte(line, '=')
+	if eq < 0 {
+		return fmt.Errorf("expected \"key = value\"")
+	}
+	key := strings.ToLower(strings.TrimSpace(line[:eq]))
+	value := strings.TrimSpace(line[eq+1:])
+
+	if key == "base" {
+		base, ok := ThemeNamed(value)
+		if !ok {
+			return fmt.Errorf("unknown theme %q (expected one of: %s)", value, strings.Join(ThemeNames(), ", "))
+		}
+		*t = base
+		return nil
+	}
+
+	levelName, setting := key, ""
+	if dot := strings.IndexByte(key, '.'); dot >= 0 {
+		levelName, setting = key[:dot], key[dot+1:]
+	}
+	level, ok := levelNamed(levelName)
+	if !ok {
+		return fmt.Errorf("unknown level %q (expected base, or one of: %s)", levelName, strings.Join(levelNames(), ", "))
+	}
+
+	switch setting {
+	case "label":
+		t.Labels[level] = value
+		return nil
+	case "", "primary", "secondary":
+	default:
+		return fmt.Errorf("unknown setting %q (expected %s, %s.primary, %s.secondary, or %s.label)", key, levelName, levelName, levelName, levelName)
+	}
+
+	var colors []string
+	if setting == "" {
+		colors = strings.Split(value, ",")
+		if len(colors) > 2 {
+			return fmt.Errorf("expected at most two colors (primary, secondary)")
+		}
+	} else {
+		colors = []string{value}
+	}
+	for i, s := range colors {
+		c, err := ParseColor(s)
+		if err != nil {
+			return err
+		}
+		if setting == "secondary" || i == 1 {
+			t.Palette[level][1] = c
+		} else {
+			t.Palette[level][0] = c
+		}
+	}
+	return nil
+}
+
+func levelNamed(name string) (Level, bool) {
+	for l := levelMin; l < levelMax; l++ {
+		if l.String() == name {
+			return l, true
+		}
+	}
+	return 0, false
+}
+
+var colorNames = map[string]Color{
+	"black":       Black,
+	"darkred":     DarkRed,
+	"darkgreen":   DarkGreen,
+	"darkyellow":  DarkYellow,
+	"darkblue":    DarkBlue,
+	"darkmagenta": DarkMagenta,
+	"darkcyan":    DarkCyan,
+	"lightgray":   LightGray,
+	"darkgray":    DarkGray,
+	"red":         Red,
+	"green":       Green,
+	"yellow":      Yellow,
+	"blue":        Blue,
+	"magenta":     Magenta,
+	"cyan":        Cyan,
+	"white":       White,
+	"default":     DefaultColor,
+}
+
+// ParseColor parses a color as described in ParseTheme (e.g. "red", "208", "#ff8000",
+// "white/darkred+bold").
+func ParseColor(s string) (Color, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	parts := strings.Split(s, "+")
+
+	color := parts[0]
+	var bg string
+	if slash := strings.IndexByte(color, '/'); slash >= 0 {
+		color, bg = color[:slash], color[slash+1:]
+	}
+
+	c, err := parseSingleColor(color)
+	if err != nil {
+		return 0, err
+	}
+	if len(bg) > 0 {
+		b, err := parseSingleColor(bg)
+		if err != nil {
+			return 0, err
+		}
+		c = c.Bg(b)
+	}
+
+	for _, style := range parts[1:] {
+		switch strings.TrimSpace(style) {
+		case "bold":
+			c = c.Bold()
+		case "dim":
+			c = c.Dim()
+		case "italic":
+			c = c.Italic()
+		case "underline":
+			c = c.Underline()
+		default:
+			return 0, fmt.Errorf("unknown style %q (expected bold, dim, italic, or underline)", style)
+		}
+	}
+	return c, nil
+}
+
+func parseSingleColor(s string) (Color, error) {
+	s = strings.TrimSpace(s)
+	if c, ok := colorNames[s]; ok {
+		return c, nil
+	}
+	if strings.HasPrefix(s, "#") && len(s) == 7 {
+		if v, err := strconv.ParseUint(s[1:], 16, 32); err == nil {
+			return RGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil
+		}
+	}
+	if v, err := strconv.ParseUint(s, 10, 8); err == nil {
+		return Color256(uint8(v)), nil
+	}
+	return 0, fmt.Errorf("unknown color %q (expected a color name like \"red\" or \"darkgray\", a number from 0 to 255, or #rrggbb)", s)
+}
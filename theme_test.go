@@ -0,0 +1,155 @@
+package frog
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_ParseTheme(t *testing.T) {
+	spec := `
+# a comment
+base = dark
+info = white, lightgray   # both colors
+warning.primary = #ff8000+bold  # 24-bit color with a style
+warning.secondary = 208
+error = white/darkred+underline
+error.label = [ERROR]
+`
+	theme, err := ParseTheme(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := DarkPalette
+	expected[Info] = [2]Color{White, LightGray}
+	expected[Warning] = [2]Color{RGB(255, 128, 0).Bold(), Color256(208)}
+	expected[Error][0] = White.Bg(DarkRed).Underline()
+	if theme.Palette != expected {
+		t.Errorf("unexpected palette:\nexpected: %v\nactual:   %v", expected, theme.Palette)
+	}
+	if theme.Labels[Error] != "[ERROR]" || theme.Labels[Info] != "" {
+		t.Errorf("unexpected labels: %q", theme.Labels)
+	}
+
+	// semicolons work in place of newlines
+	inline, err := ParseTheme("base=dark; info=white,lightgray; warning.primary=#ff8000+bold; warning.secondary=208; error=white/darkred+underline; error.label=[ERROR]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inline != theme {
+		t.Errorf("expected inline spec to match multi-line spec")
+	}
+}
+
+func Test_ThemesSetEveryLevel(t *testing.T) {
+	// a level left out of a Palette literal is silently black on black
+	for _, name := range ThemeNames() {
+		p := themes[name].Palette
+		for level := Transient; level < levelMax; level++ {
+			if p[level] == [2]Color{} {
+				t.Errorf("theme %q has no colors for level %s", name, level)
+			}
+		}
+	}
+}
+
+func Test_ParseThemeErrors(t *testing.T) {
+	cases := []struct {
+		Spec     string
+		Expected string
+	}{
+		{"info", `line 1 ("info"): expected "key = value"`},
+		{"base = nope", `unknown theme "nope" (expected one of: colorblind-safe, dark, default, high-contrast, light-background)`},
+		{"\ninfo = blu", `line 2 ("info = blu"): unknown color "blu"`},
+		{"debug = red", `unknown level "debug"`},
+		{"info.color = red", `unknown setting "info.color"`},
+		{"info = red, red, red", `expected at most two colors`},
+		{"info = red+blink", `unknown style "blink"`},
+		{"info = 256", `unknown color "256"`},
+	}
+	for _, tc := range cases {
+		_, err := ParseTheme(tc.Spec)
+		if err == nil || !strings.Contains(err.Error(), tc.Expected) {
+			t.Errorf("%q: expected error containing %q, got %v", tc.Spec, tc.Expected, err)
+		}
+	}
+}
+
+func Test_ThemeNamed(t *testing.T) {
+	for _, name := range ThemeNames() {
+		if _, ok := ThemeNamed(name); !ok {
+			t.Errorf("expected theme %q to exist", name)
+		}
+	}
+	if theme, ok := ThemeNamed(" Dark "); !ok || theme.Palette != DarkPalette {
+		t.Errorf("expected theme names to ignore case and spaces")
+	}
+}
+
+func Test_LoadTheme(t *testing.T) {
+	dir, err := ioutil.TempDir("", "frog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "theme.txt")
+	if err := ioutil.WriteFile(path, []byte("info.label = INFO\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	theme, err := LoadTheme(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.Labels[Info] != "INFO" {
+		t.Errorf("expected info label to be loaded, got %q", theme.Labels[Info])
+	}
+
+	if _, err := LoadTheme(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+func Test_ThemeFromEnv(t *testing.T) {
+	defer setEnv(EnvVarPalette, "")()
+	if _, ok, err := ThemeFromEnv(); ok || err != nil {
+		t.Errorf("expected no theme, got %v, %v", ok, err)
+	}
+
+	os.Setenv(EnvVarPalette, "high-contrast") //nolint:errcheck
+	if theme, ok, err := ThemeFromEnv(); !ok || err != nil || theme != themes["high-contrast"] {
+		t.Errorf("expected named theme, got %v, %v", ok, err)
+	}
+
+	os.Setenv(EnvVarPalette, "info.label=INFO") //nolint:errcheck
+	if theme, ok, err := ThemeFromEnv(); !ok || err != nil || theme.Labels[Info] != "INFO" {
+		t.Errorf("expected inline theme, got %v, %v", ok, err)
+	}
+
+	os.Setenv(EnvVarPalette, "info=nope") //nolint:errcheck
+	if _, ok, err := ThemeFromEnv(); ok || err == nil || !strings.HasPrefix(err.Error(), EnvVarPalette+": ") {
+		t.Errorf("expected an error that names the env var, got %v, %v", ok, err)
+	}
+}
+
+func Test_POTheme(t *testing.T) {
+	theme, err := ParseTheme("info.label = INFO; error.label = ERROR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	log := NewUnbuffered(&buf, (&TextPrinter{printLevel: true}).SetOptions(POTheme(theme)))
+	log.Info("hello")
+	log.Warning("unchanged")
+	log.Error("oops")
+	log.Close()
+
+	expected := "\x1b[37mINFO \x1b[97mhello\x1b[0m\n\x1b[33m[WRN] \x1b[93munchanged\x1b[0m\n\x1b[31mERROR \x1b[91moops\x1b[0m\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
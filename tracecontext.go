@@ -0,0 +1,263 @@
+package frog
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// TraceparentHeader is the name of the W3C Trace Context HTTP header (see ParseTraceparent).
+const TraceparentHeader = "traceparent"
+
+// Default names of the fields added by TraceContext.Fielders.
+const (
+	TraceIDFieldName    = "trace_id"
+	SpanIDFieldName     = "span_id"
+	TraceFlagsFieldName = "trace_flags"
+)
+
+// traceShortLen is the number of hex digits of trace and span ids that text-based printers show
+const traceShortLen = 8
+
+// TraceID is a W3C Trace Context (and OpenTelemetry) trace id.
+type TraceID [16]byte
+
+// IsValid returns false if the trace id is all zeros.
+func (id TraceID) IsValid() bool { return id != TraceID{} }
+
+// String returns the trace id as 32 lowercase hex digits.
+func (id TraceID) String() string { return hex.EncodeToString(id[:]) }
+
+// SpanID is a W3C Trace Context (and OpenTelemetry) span id (aka parent id).
+type SpanID [8]byte
+
+// IsValid returns false if the span id is all zeros.
+func (id SpanID) IsValid() bool { return id != SpanID{} }
+
+// String returns the span id as 16 lowercase hex digits.
+func (id SpanID) String() string { return hex.EncodeToString(id[:]) }
+
+// TraceFlags are the W3C Trace Context trace flags.
+type TraceFlags byte
+
+// TraceFlagsSampled is set when the caller may have recorded the trace.
+const TraceFlagsSampled TraceFlags = 0x01
+
+// Sampled returns true if the sampled flag is set.
+func (f TraceFlags) Sampled() bool { return f&TraceFlagsSampled != 0 }
+
+// String returns the flags as 2 lowercase hex digits.
+func (f TraceFlags) String() string { return hex.EncodeToString([]byte{byte(f)}) }
+
+// TraceContext identifies a span within a trace, so that log lines can be linked to traces.
+type TraceContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Flags   TraceFlags
+}
+
+// IsValid returns true if both the trace id and span id are valid (i.e. not all zeros).
+func (tc TraceContext) IsValid() bool {
+	return tc.TraceID.IsValid() && tc.SpanID.IsValid()
+}
+
+// ParseTraceparent parses the value of a W3C Trace Context traceparent header, e.g.:
+//
+//	00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+//
+// Versions after 00 are parsed as if they were 00, ignoring anything after the flags, as the spec
+// requires.
+func ParseTraceparent(s string) (TraceContext, error) {
+	s = strings.TrimSpace(s)
+	// version (2) + trace id (32) + span id (16) + flags (2), plus 3 dashes
+	const length = 55
+	if len(s) < length {
+		return TraceContext{}, fmt.Errorf("frog: invalid traceparent %q: expected at least %d characters", s, length)
+	}
+	if s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return TraceContext{}, fmt.Errorf("frog: invalid traceparent %q: expected version-traceid-spanid-flags", s)
+	}
+
+	var version [1]byte
+	if err := decodeLowerHex(version[:], s[0:2]); err != nil {
+		return TraceContext{}, fmt.Errorf("frog: invalid traceparent %q: version: %w", s, err)
+	}
+	switch {
+	case version[0] == 0xff:
+		return TraceContext{}, fmt.Errorf("frog: invalid traceparent %q: version ff is not allowed", s)
+	case version[0] == 0 && len(s) != length:
+		return TraceContext{}, fmt.Errorf("frog: invalid traceparent %q: expected %d characters for version 00", s, length)
+	case len(s) > length && s[length] != '-':
+		return TraceContext{}, fmt.Errorf("frog: invalid traceparent %q: expected a '-' after the flags", s)
+	}
+
+	var tc TraceContext
+	if err := decodeLowerHex(tc.TraceID[:], s[3:35]); err != nil {
+		return TraceContext{}, fmt.Errorf("frog: invalid traceparent %q: trace id: %w", s, err)
+	}
+	if err := decodeLowerHex(tc.SpanID[:], s[36:52]); err != nil {
+		return TraceContext{}, fmt.Errorf("frog: invalid traceparent %q: span id: %w", s, err)
+	}
+	var flags [1]byte
+	if err := decodeLowerHex(flags[:], s[53:55]); err != nil {
+		return TraceContext{}, fmt.Errorf("frog: invalid traceparent %q: flags: %w", s, err)
+	}
+	tc.Flags = TraceFlags(flags[0])
+
+	if !tc.TraceID.IsValid() {
+		return TraceContext{}, fmt.Errorf("frog: invalid traceparent %q: trace id is all zeros", s)
+	}
+	if !tc.SpanID.IsValid() {
+		return TraceContext{}, fmt.Errorf("frog: invalid traceparent %q: span id is all zeros", s)
+	}
+	return tc, nil
+}
+
+// decodeLowerHex decodes hex digits into dst, which must be exactly the right size. Uppercase
+// digits are not allowed by the traceparent spec.
+func decodeLowerHex(dst []byte, s string) error {
+	if strings.ToLower(s) != s {
+		return fmt.Errorf("expected lowercase hex digits, got %q", s)
+	}
+	if _, err := hex.Decode(dst, []byte(s)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Traceparent returns the trace context as a (version 00) traceparent header value.
+func (tc TraceContext) Traceparent() string {
+	var sb strings.Builder
+	sb.Grow(55)
+	sb.WriteString("00-")
+	sb.WriteString(tc.TraceID.String())
+	sb.WriteByte('-')
+	sb.WriteString(tc.SpanID.String())
+	sb.WriteByte('-')
+	sb.WriteString(tc.Flags.String())
+	return sb.String()
+}
+
+// Fielders returns the trace id, span id, and trace flags as fields (named "trace_id", "span_id",
+// and "trace_flags").
+func (tc TraceContext) Fielders() []Fielder {
+	return []Fielder{
+		TraceIDField(TraceIDFieldName, tc.TraceID),
+		SpanIDField(SpanIDFieldName, tc.SpanID),
+		TraceFlagsField(TraceFlagsFieldName, tc.Flags),
+	}
+}
+
+// Context
+
+// traceCtxKey is the context key for the TraceContext stored by ContextWithTrace
+type traceCtxKey struct{}
+
+// ContextWithTrace returns a copy of the passed in context that holds the passed in TraceContext
+// (e.g. one parsed from an incoming request's traceparent header).
+func ContextWithTrace(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceCtxKey{}, tc)
+}
+
+// TraceFromContext returns the TraceContext stored by ContextWithTrace, or false if there isn't a
+// valid one (or if ctx is nil).
+func TraceFromContext(ctx context.Context) (TraceContext, bool) {
+	if ctx == nil {
+		return TraceContext{}, false
+	}
+	tc, ok := ctx.Value(traceCtxKey{}).(TraceContext)
+	return tc, ok && tc.IsValid()
+}
+
+// TraceContextExtractor gets the current TraceContext from a context.Context. This lets frog pick
+// up trace context from a tracing library without depending on it. For example, with OpenTelemetry:
+//
+//	frog.TraceContextExtractorFunc(func(ctx context.Context) (frog.TraceContext, bool) {
+//		sc := trace.SpanContextFromContext(ctx)
+//		return frog.TraceContext{
+//			TraceID: frog.TraceID(sc.TraceID()),
+//			SpanID:  frog.SpanID(sc.SpanID()),
+//			Flags:   frog.TraceFlags(sc.TraceFlags()),
+//		}, sc.IsValid()
+//	})
+type TraceContextExtractor interface {
+	TraceContext(ctx context.Context) (TraceContext, bool)
+}
+
+// TraceContextExtractorFunc adapts a func to a TraceContextExtractor.
+type TraceContextExtractorFunc func(ctx context.Context) (TraceContext, bool)
+
+func (fn TraceContextExtractorFunc) TraceContext(ctx context.Context) (TraceContext, bool) {
+	return fn(ctx)
+}
+
+// TraceFields returns a ContextExtractor (see RegisterContextExtractor) that adds the trace_id,
+// span_id, and trace_flags fields whenever the passed in TraceContextExtractor finds a valid
+// TraceContext. If e is nil, TraceFromContext is used.
+func TraceFields(e TraceContextExtractor) ContextExtractor {
+	if e == nil {
+		e = TraceContextExtractorFunc(TraceFromContext)
+	}
+	return ContextExtractorFunc(func(ctx context.Context) []Fielder {
+		tc, ok := e.TraceContext(ctx)
+		if !ok || !tc.IsValid() {
+			return nil
+		}
+		return tc.Fielders()
+	})
+}
+
+// Fields
+
+// TraceIDField adds a field for a trace id. JSONPrinter renders all 32 hex digits, while text-based
+// printers render just the first 8 (which is usually enough to find the trace).
+func TraceIDField(name string, id TraceID) FieldTraceID {
+	return FieldTraceID{Name: name, Value: id}
+}
+
+// SpanIDField adds a field for a span id. JSONPrinter renders all 16 hex digits, while text-based
+// printers render just the first 8.
+func SpanIDField(name string, id SpanID) FieldSpanID {
+	return FieldSpanID{Name: name, Value: id}
+}
+
+// TraceFlagsField adds a field for trace flags, rendered as 2 hex digits (e.g. "01").
+func TraceFlagsField(name string, flags TraceFlags) FieldTraceFlags {
+	return FieldTraceFlags{Name: name, Value: flags}
+}
+
+// TraceID
+
+type FieldTraceID struct {
+	Name  string
+	Value TraceID
+}
+
+func (f FieldTraceID) Field() Field {
+	s := f.Value.String()
+	return Field{Name: f.Name, Value: s, TextValue: s[:traceShortLen], IsJSONString: true, IsJSONSafe: true}
+}
+
+// SpanID
+
+type FieldSpanID struct {
+	Name  string
+	Value SpanID
+}
+
+func (f FieldSpanID) Field() Field {
+	s := f.Value.String()
+	return Field{Name: f.Name, Value: s, TextValue: s[:traceShortLen], IsJSONString: true, IsJSONSafe: true}
+}
+
+// TraceFlags
+
+type FieldTraceFlags struct {
+	Name  string
+	Value TraceFlags
+}
+
+func (f FieldTraceFlags) Field() Field {
+	return Field{Name: f.Name, Value: f.Value.String(), IsJSONString: true, IsJSONSafe: true}
+}
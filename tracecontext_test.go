@@ -0,0 +1,113 @@
+package frog
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func Test_ParseTraceparent(t *testing.T) {
+	tc, err := ParseTraceparent(testTraceparent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("unexpected trace id %s", tc.TraceID)
+	}
+	if tc.SpanID.String() != "00f067aa0ba902b7" {
+		t.Errorf("unexpected span id %s", tc.SpanID)
+	}
+	if !tc.Flags.Sampled() {
+		t.Errorf("expected the sampled flag to be set")
+	}
+	if actual := tc.Traceparent(); actual != testTraceparent {
+		t.Errorf("expected round trip to %s, got %s", testTraceparent, actual)
+	}
+
+	// future versions may add fields after the flags
+	future := "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-what-the-future-holds"
+	tc, err = ParseTraceparent(future)
+	if err != nil {
+		t.Errorf("expected future version to parse, got %v", err)
+	} else if tc.Flags.Sampled() || tc.Traceparent() != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00" {
+		t.Errorf("unexpected trace context from future version: %s", tc.Traceparent())
+	}
+
+	invalid := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"00_4bf92f3577b34da6a3ce929d0e0e4736_00f067aa0ba902b7_01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473g-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0x",
+		"cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01extra",
+	}
+	for _, s := range invalid {
+		if _, err := ParseTraceparent(s); err == nil {
+			t.Errorf("expected an error parsing %q", s)
+		}
+	}
+}
+
+func Test_TraceFields(t *testing.T) {
+	tc, _ := ParseTraceparent(testTraceparent)
+	testFieldOutput(t, TraceIDField("trace_id", tc.TraceID),
+		"trace_id=4bf92f35", `"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`)
+	testFieldOutput(t, SpanIDField("span_id", tc.SpanID),
+		"span_id=00f067aa", `"span_id":"00f067aa0ba902b7"`)
+	testFieldOutput(t, TraceFlagsField("trace_flags", tc.Flags),
+		"trace_flags=01", `"trace_flags":"01"`)
+}
+
+func Test_TraceContextExtractor(t *testing.T) {
+	defer RegisterContextExtractor(TraceFields(nil))()
+
+	var buf bytes.Buffer
+	log := NewUnbuffered(&buf, &TextPrinter{printLevel: true})
+	ctx := NewContext(context.Background(), log)
+
+	InfoCtx(ctx, "no trace")
+	InfoCtx(ContextWithTrace(ctx, TraceContext{}), "invalid trace")
+	tc, _ := ParseTraceparent(testTraceparent)
+	InfoCtx(ContextWithTrace(ctx, tc), "traced")
+
+	expected := "" +
+		"[nfo] no trace\n" +
+		"[nfo] invalid trace\n" +
+		"[nfo] traced    trace_id=4bf92f35 span_id=00f067aa trace_flags=01\n"
+	if buf.String() != expected {
+		t.Errorf("\nexpected:\n%s\nactual:\n%s", expected, buf.String())
+	}
+}
+
+func Test_TraceFromNilContext(t *testing.T) {
+	if _, ok := TraceFromContext(nil); ok {
+		t.Errorf("expected no trace context from a nil context")
+	}
+}
+
+func Test_TraceContextExtractorFunc(t *testing.T) {
+	tc, _ := ParseTraceparent(testTraceparent)
+	e := TraceFields(TraceContextExtractorFunc(func(ctx context.Context) (TraceContext, bool) {
+		return tc, ctx.Value(testCtxKey("traced")) != nil
+	}))
+
+	if fields := e.Extract(context.Background()); len(fields) != 0 {
+		t.Errorf("expected no fields, got %v", fields)
+	}
+	fields := e.Extract(context.WithValue(context.Background(), testCtxKey("traced"), true))
+	if len(fields) != 3 {
+		t.Fatalf("expected 3 fields, got %v", fields)
+	}
+	for i, name := range []string{TraceIDFieldName, SpanIDFieldName, TraceFlagsFieldName} {
+		if actual := fields[i].Field().Name; actual != name {
+			t.Errorf("expected field %d to be %s, got %s", i, name, actual)
+		}
+	}
+}
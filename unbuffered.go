@@ -8,14 +8,19 @@ import (
 type Unbuffered struct {
 	writer   io.Writer
 	prn      Printer
-	minLevel Level
+	minLevel atomicLevel
+	levels   LevelRegistry
+	exitCode int // exit code after a Fatal line (never changes after creation)
 }
 
-func NewUnbuffered(writer io.Writer, prn Printer) *Unbuffered {
+// NewUnbuffered creates a root Logger that renders and writes each line before returning.
+func NewUnbuffered(writer io.Writer, prn Printer, opts ...RootOption) *Unbuffered {
+	cfg := newRootConfig(opts)
 	return &Unbuffered{
 		writer:   writer,
-		prn:      prn,
-		minLevel: Info,
+		prn:      cfg.preparePrinter(prn),
+		minLevel: atomicLevel{v: int32(Info)},
+		exitCode: cfg.fatalExitCode,
 	}
 }
 
@@ -23,22 +28,35 @@ func (l *Unbuffered) Close() {
 	// this space intentionally left blank (nothing to cleanup or flush)
 }
 
+func (l *Unbuffered) Flush() {
+	// this space intentionally left blank (every line is written before LogImpl returns)
+}
+
 func (l *Unbuffered) MinLevel() Level {
-	return l.minLevel
+	return l.minLevel.Load()
 }
 
 func (l *Unbuffered) SetMinLevel(level Level) Logger {
-	l.minLevel = level
+	l.minLevel.Store(level)
 	return l
 }
 
+// LevelRegistry returns the registry of min levels for named Loggers (see Named).
+func (l *Unbuffered) LevelRegistry() *LevelRegistry {
+	return &l.levels
+}
+
+func (l *Unbuffered) fatalExitCode() int {
+	return l.exitCode
+}
+
 func (l *Unbuffered) LogImpl(level Level, msg string, fielders []Fielder, opts []PrinterOption, d ImplData) {
-	d.MergeMinLevel(l.minLevel)
-	if level < d.MinLevel {
+	d.MergeMinLevel(l.levels.minLevel(d.LoggerName, l.minLevel.Load()))
+	if level < d.MinLevel && !d.ignoreMinLevel {
 		return
 	}
 
-	fmt.Fprintf(l.writer, "%s\n", l.prn.Render(level, opts, msg, FieldifyAndAppend(d.Fields, fielders)))
+	fmt.Fprintf(l.writer, "%s\n", l.prn.Render(level, opts, msg, d.AssembleFields(fielders)))
 }
 
 func (l *Unbuffered) Transient(msg string, fielders ...Fielder) Logger {
@@ -46,6 +64,11 @@ func (l *Unbuffered) Transient(msg string, fielders ...Fielder) Logger {
 	return l
 }
 
+func (l *Unbuffered) Trace(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Trace, msg, fielders, nil, ImplData{})
+	return l
+}
+
 func (l *Unbuffered) Verbose(msg string, fielders ...Fielder) Logger {
 	l.LogImpl(Verbose, msg, fielders, nil, ImplData{})
 	return l
@@ -66,7 +89,16 @@ func (l *Unbuffered) Error(msg string, fielders ...Fielder) Logger {
 	return l
 }
 
+func (l *Unbuffered) Fatal(msg string, fielders ...Fielder) Logger {
+	l.LogImpl(Fatal, msg, fielders, nil, ImplData{})
+	fatalExit(l)
+	return l
+}
+
 func (l *Unbuffered) Log(level Level, msg string, fielders ...Fielder) Logger {
 	l.LogImpl(level, msg, fielders, nil, ImplData{})
+	if level == Fatal {
+		fatalExit(l)
+	}
 	return l
 }